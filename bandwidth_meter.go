@@ -0,0 +1,108 @@
+package rtcp
+
+import (
+	"sync"
+	"time"
+)
+
+// BandwidthMeter measures the rate at which RTCP bytes are sent or
+// received over a sliding window, for checking a session against the RTCP
+// bandwidth share of RFC 3550 section 6.2. The end of the window is the
+// time of the most recent Record call, so the meter never reads a clock.
+//
+// The zero value is a BandwidthMeter that keeps every sample. A
+// BandwidthMeter is safe for concurrent use.
+type BandwidthMeter struct {
+	mu        sync.Mutex
+	maxWindow time.Duration
+	samples   []bandwidthSample
+}
+
+type bandwidthSample struct {
+	n  int
+	at time.Time
+}
+
+// NewBandwidthMeter returns a BandwidthMeter that can report rates over
+// windows of up to maxWindow. Older samples are discarded. A maxWindow of
+// 0 keeps every sample.
+func NewBandwidthMeter(maxWindow time.Duration) *BandwidthMeter {
+	return &BandwidthMeter{maxWindow: maxWindow}
+}
+
+// Record adds n bytes seen at time at. Samples are expected in time order.
+func (m *BandwidthMeter) Record(n int, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.samples = append(m.samples, bandwidthSample{n: n, at: at})
+	if m.maxWindow == 0 {
+		return
+	}
+
+	cutoff := at.Add(-m.maxWindow)
+	i := 0
+	for i < len(m.samples) && !m.samples[i].at.After(cutoff) {
+		i++
+	}
+	if i > 0 {
+		m.samples = append(m.samples[:0], m.samples[i:]...)
+	}
+}
+
+// BytesPerSecond returns the average rate of the bytes recorded during the
+// window ending at the most recent sample. window is capped to the
+// maxWindow the meter was created with, if any.
+func (m *BandwidthMeter) BytesPerSecond(window time.Duration) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.maxWindow != 0 && window > m.maxWindow {
+		window = m.maxWindow
+	}
+	if window <= 0 || len(m.samples) == 0 {
+		return 0
+	}
+
+	cutoff := m.samples[len(m.samples)-1].at.Add(-window)
+	total := 0
+	for i := len(m.samples) - 1; i >= 0 && m.samples[i].at.After(cutoff); i-- {
+		total += m.samples[i].n
+	}
+	return float64(total) / window.Seconds()
+}
+
+// Reset discards every recorded sample, as when the stream being measured
+// restarts. maxWindow is kept.
+func (m *BandwidthMeter) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.samples = m.samples[:0]
+}
+
+// Overhead returns the number of octets of the marshaled form of p that
+// are RTCP framing rather than payload: the 4-octet common header of each
+// packet in p, plus the padding octets at the end of any packet with the
+// padding bit set. Everything else, including the sender and media SSRCs,
+// counts as payload. Subtract Overhead from the marshaled size to get the
+// payload size. Overhead returns 0 if p cannot be marshaled.
+func Overhead(p Packet) int {
+	data, err := p.Marshal()
+	if err != nil {
+		return 0
+	}
+
+	overhead := 0
+	err = Iterate(data, func(_ PacketType, pkt []byte) (bool, error) {
+		overhead += headerLength
+		if pkt[0]&(paddingMask<<paddingShift) != 0 {
+			overhead += int(pkt[len(pkt)-1])
+		}
+		return false, nil
+	})
+	if err != nil {
+		return 0
+	}
+	return overhead
+}
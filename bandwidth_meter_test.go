@@ -0,0 +1,89 @@
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandwidthMeter(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewBandwidthMeter(10 * time.Second)
+	assert.Equal(t, float64(0), m.BytesPerSecond(time.Second))
+
+	// 100 bytes every 500ms for 20 seconds
+	for i := 1; i <= 40; i++ {
+		m.Record(100, start.Add(time.Duration(i)*500*time.Millisecond))
+	}
+
+	assert.Equal(t, float64(200), m.BytesPerSecond(time.Second))
+	assert.Equal(t, float64(200), m.BytesPerSecond(5*time.Second))
+	// Capped to the 10s the meter keeps
+	assert.Equal(t, float64(200), m.BytesPerSecond(time.Minute))
+	assert.Len(t, m.samples, 20)
+
+	// A burst raises the short window rate more than the long one
+	m.Record(1000, start.Add(20*time.Second+250*time.Millisecond))
+	assert.Equal(t, float64(1200), m.BytesPerSecond(time.Second))
+	assert.Equal(t, float64(300), m.BytesPerSecond(10*time.Second))
+}
+
+func TestBandwidthMeterZeroValue(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var m BandwidthMeter
+	for i := 1; i <= 40; i++ {
+		m.Record(100, start.Add(time.Duration(i)*500*time.Millisecond))
+	}
+
+	assert.Equal(t, float64(200), m.BytesPerSecond(time.Second))
+	// Nothing is discarded, so the whole 20s is available
+	assert.Equal(t, float64(200), m.BytesPerSecond(20*time.Second))
+	assert.Len(t, m.samples, 40)
+}
+
+func TestOverhead(t *testing.T) {
+	sr := &SenderReport{
+		SSRC: 0x902f9e2e,
+		Reports: []ReceptionReport{
+			{SSRC: 0xbc5e9a40},
+			{SSRC: 0xbc5e9a41},
+		},
+	}
+	pli := &PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0xbc5e9a40}
+
+	// Report blocks are payload, so the SR costs no more framing than the
+	// bare PLI.
+	assert.Equal(t, headerLength, Overhead(sr))
+	assert.Equal(t, headerLength, Overhead(pli))
+
+	padded := &SenderReport{
+		SSRC:              0x902f9e2e,
+		Reports:           sr.Reports,
+		ProfileExtensions: []byte{0x01, 0x02},
+		paddingLength:     2,
+	}
+	assert.Equal(t, headerLength+2, Overhead(padded))
+
+	assert.Equal(t, 3*headerLength, Overhead(&CompoundPacket{
+		sr,
+		NewCNAMESourceDescription(0x902f9e2e, "cname"),
+		pli,
+	}))
+
+	assert.Equal(t, 0, Overhead(&Goodbye{Sources: make([]uint32, 32)}))
+}
+
+func TestBandwidthMeterReset(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewBandwidthMeter(10 * time.Second)
+	m.Record(1000, start)
+	m.Record(1000, start.Add(time.Second))
+
+	m.Reset()
+	assert.Equal(t, float64(0), m.BytesPerSecond(time.Second))
+
+	m.Record(100, start.Add(2*time.Second))
+	assert.Equal(t, float64(100), m.BytesPerSecond(time.Second))
+	assert.Len(t, m.samples, 1)
+}
@@ -0,0 +1,235 @@
+package rtcp
+
+import (
+	"testing"
+)
+
+// benchmarkPackets returns one representative instance of each major packet
+// type. They are used both by the marshal/unmarshal benchmarks and by the
+// allocation regression guard below.
+func benchmarkPackets() []struct {
+	Name   string
+	Packet Packet
+} {
+	return []struct {
+		Name   string
+		Packet Packet
+	}{
+		{
+			Name: "SenderReport",
+			Packet: &SenderReport{
+				SSRC:        0x902f9e2e,
+				NTPTime:     0xda8bd1fcdddda05a,
+				RTPTime:     0xaaf4edd5,
+				PacketCount: 1,
+				OctetCount:  2,
+				Reports: []ReceptionReport{{
+					SSRC:               0xbc5e9a40,
+					LastSequenceNumber: 0x46e1,
+					Jitter:             273,
+					LastSenderReport:   0x9f36432,
+					Delay:              150137,
+				}},
+			},
+		},
+		{
+			Name: "ReceiverReport",
+			Packet: &ReceiverReport{
+				SSRC: 0x902f9e2e,
+				Reports: []ReceptionReport{{
+					SSRC:               0xbc5e9a40,
+					LastSequenceNumber: 0x46e1,
+					Jitter:             273,
+					LastSenderReport:   0x9f36432,
+					Delay:              150137,
+				}},
+			},
+		},
+		{
+			Name:   "SourceDescription",
+			Packet: NewCNAMESourceDescription(0x902f9e2e, "{9c00eb92-1afb-9d49-a47d-91f64eee69f5}"),
+		},
+		{
+			Name: "Goodbye",
+			Packet: &Goodbye{
+				Sources: []uint32{0x902f9e2e},
+				Reason:  "because",
+			},
+		},
+		{
+			Name: "PictureLossIndication",
+			Packet: &PictureLossIndication{
+				SenderSSRC: 0x902f9e2e,
+				MediaSSRC:  0x902f9e2e,
+			},
+		},
+		{
+			Name: "FullIntraRequest",
+			Packet: &FullIntraRequest{
+				SenderSSRC: 0x0,
+				MediaSSRC:  0x4bc4fcb4,
+				FIR:        []FIREntry{{SSRC: 0x12345678, SequenceNumber: 0x42}},
+			},
+		},
+		{
+			Name: "TransportLayerNack",
+			Packet: &TransportLayerNack{
+				SenderSSRC: 0x902f9e2e,
+				MediaSSRC:  0x902f9e2e,
+				Nacks:      []NackPair{{PacketID: 0xaaaa, LostPackets: 0x5555}},
+			},
+		},
+		{
+			Name: "ReceiverEstimatedMaximumBitrate",
+			Packet: &ReceiverEstimatedMaximumBitrate{
+				SenderSSRC: 1,
+				Bitrate:    8927168,
+				SSRCs:      []uint32{1215622422},
+			},
+		},
+		{
+			Name: "TransportLayerCC",
+			Packet: &TransportLayerCC{
+				Header: Header{
+					Padding: true,
+					Count:   FormatTCC,
+					Type:    TypeTransportSpecificFeedback,
+					Length:  5,
+				},
+				SenderSSRC:         4195875351,
+				MediaSSRC:          1124282272,
+				BaseSequenceNumber: 153,
+				PacketStatusCount:  1,
+				ReferenceTime:      4057090,
+				FbPktCount:         23,
+				PacketChunks: []PacketStatusChunk{
+					&RunLengthChunk{
+						Type:               TypeTCCRunLengthChunk,
+						PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta,
+						RunLength:          1,
+					},
+				},
+				RecvDeltas: []*RecvDelta{
+					{Type: TypeTCCPacketReceivedSmallDelta, Delta: 37000},
+				},
+			},
+		},
+		{
+			Name: "ExtendedReport",
+			Packet: &ExtendedReport{
+				SenderSSRC: 0x01020304,
+				Reports: []ReportBlock{
+					&DLRRReportBlock{
+						Reports: []DLRRReport{{SSRC: 0x88888888, LastRR: 0x12345678, DLRR: 0x99999999}},
+					},
+				},
+			},
+		},
+		{
+			Name: "CompoundPacket",
+			Packet: &CompoundPacket{
+				&ReceiverReport{SSRC: 0x902f9e2e},
+				NewCNAMESourceDescription(0x902f9e2e, "cname"),
+				&PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x902f9e2e},
+			},
+		},
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	for _, test := range benchmarkPackets() {
+		test := test
+		b.Run(test.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := test.Packet.Marshal(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	for _, test := range benchmarkPackets() {
+		test := test
+		data, err := test.Packet.Marshal()
+		if err != nil {
+			b.Fatalf("Marshal(%s) err = %v", test.Name, err)
+		}
+		b.Run(test.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if _, _, _, err := Unmarshal(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkReceiverEstimatedMaximumBitrateMarshalTo(b *testing.B) {
+	p := ReceiverEstimatedMaximumBitrate{
+		SenderSSRC: 1,
+		Bitrate:    8927168,
+		SSRCs:      []uint32{1215622422},
+	}
+	buf := make([]byte, p.MarshalSize())
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := p.MarshalTo(buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMarshalAllocations(t *testing.T) {
+	// Upper bounds on the number of allocations a single Marshal call
+	// may perform. Lower these as marshaling becomes cheaper; a test
+	// failure here means a change made marshaling more expensive.
+	maxAllocs := map[string]float64{
+		"SenderReport":                    2,
+		"ReceiverReport":                  2,
+		"SourceDescription":               4,
+		"Goodbye":                         1,
+		"PictureLossIndication":           1,
+		"FullIntraRequest":                2,
+		"TransportLayerNack":              2,
+		"ReceiverEstimatedMaximumBitrate": 1,
+		"TransportLayerCC":                3,
+		"ExtendedReport":                  18,
+		"CompoundPacket":                  9,
+	}
+
+	for _, test := range benchmarkPackets() {
+		test := test
+		want, ok := maxAllocs[test.Name]
+		if !ok {
+			t.Fatalf("no allocation bound for %s", test.Name)
+		}
+		got := testing.AllocsPerRun(100, func() {
+			if _, err := test.Packet.Marshal(); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if got > want {
+			t.Errorf("Marshal(%s) allocs = %v, want <= %v", test.Name, got, want)
+		}
+	}
+
+	p := ReceiverEstimatedMaximumBitrate{
+		SenderSSRC: 1,
+		Bitrate:    8927168,
+		SSRCs:      []uint32{1215622422},
+	}
+	buf := make([]byte, p.MarshalSize())
+	if got := testing.AllocsPerRun(100, func() {
+		if _, err := p.MarshalTo(buf); err != nil {
+			t.Fatal(err)
+		}
+	}); got != 0 {
+		t.Errorf("ReceiverEstimatedMaximumBitrate.MarshalTo allocs = %v, want 0", got)
+	}
+}
@@ -139,6 +139,89 @@ func (c CompoundPacket) DestinationSSRC() []uint32 {
 	return c[0].DestinationSSRC()
 }
 
+// AllSSRCs returns every SSRC referenced by the packets in the
+// CompoundPacket, for access control and routing: the DestinationSSRC of
+// each packet and the SSRC of its sender. Each SSRC appears once, in order
+// of first appearance.
+func (c CompoundPacket) AllSSRCs() []uint32 {
+	var out []uint32
+	seen := map[uint32]struct{}{}
+	add := func(ssrc uint32) {
+		if _, ok := seen[ssrc]; !ok {
+			seen[ssrc] = struct{}{}
+			out = append(out, ssrc)
+		}
+	}
+
+	var walk func(CompoundPacket)
+	walk = func(c CompoundPacket) {
+		for _, pkt := range c {
+			if nested, ok := pkt.(*CompoundPacket); ok {
+				walk(*nested)
+				continue
+			}
+			if ssrc, ok := senderSSRC(pkt); ok {
+				add(ssrc)
+			}
+			for _, ssrc := range pkt.DestinationSSRC() {
+				add(ssrc)
+			}
+		}
+	}
+	walk(c)
+	return out
+}
+
+// senderSSRC returns the SSRC of the sender of p, for the packet types
+// that carry one.
+func senderSSRC(p Packet) (uint32, bool) {
+	switch p := p.(type) {
+	case *SenderReport:
+		return p.SSRC, true
+	case *ReceiverReport:
+		return p.SSRC, true
+	case *PictureLossIndication:
+		return p.SenderSSRC, true
+	case *SliceLossIndication:
+		return p.SenderSSRC, true
+	case *RapidResynchronizationRequest:
+		return p.SenderSSRC, true
+	case *ECNFeedback:
+		return p.SenderSSRC, true
+	case *TransportLayerNack:
+		return p.SenderSSRC, true
+	case *TransportLayerCC:
+		return p.SenderSSRC, true
+	case *FullIntraRequest:
+		return p.SenderSSRC, true
+	case *ReceiverEstimatedMaximumBitrate:
+		return p.SenderSSRC, true
+	case *CCFeedbackReport:
+		return p.SenderSSRC, true
+	case *ExtendedReport:
+		return p.SenderSSRC, true
+	}
+	return 0, false
+}
+
+// GatherReports returns the reception reports of every SenderReport and
+// ReceiverReport in the CompoundPacket, keyed by the SSRC of the reporter.
+// A reporter with more than 31 sources sends its first 31 blocks in the
+// leading SR or RR and the rest in the RRs that follow it; these are
+// merged, in packet order, into a single slice.
+func GatherReports(c CompoundPacket) map[uint32][]ReceptionReport {
+	reports := map[uint32][]ReceptionReport{}
+	for _, pkt := range c {
+		switch p := pkt.(type) {
+		case *SenderReport:
+			reports[p.SSRC] = append(reports[p.SSRC], p.Reports...)
+		case *ReceiverReport:
+			reports[p.SSRC] = append(reports[p.SSRC], p.Reports...)
+		}
+	}
+	return reports
+}
+
 func (c CompoundPacket) String() string {
 	out := "CompoundPacket\n"
 	for _, p := range c {
@@ -273,3 +273,86 @@ func TestCompoundPacketRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestGatherReports(t *testing.T) {
+	const reporter = 0x902f9e2e
+
+	blocks := make([]ReceptionReport, 40)
+	for i := range blocks {
+		blocks[i] = ReceptionReport{SSRC: uint32(i + 1), LastSequenceNumber: uint32(100 + i)}
+	}
+
+	c := CompoundPacket{
+		&SenderReport{SSRC: reporter, Reports: blocks[:31]},
+		&ReceiverReport{SSRC: reporter, Reports: blocks[31:]},
+		NewCNAMESourceDescription(reporter, "cname"),
+	}
+	assert.NoError(t, c.Validate())
+
+	data, err := c.Marshal()
+	assert.NoError(t, err)
+	var decoded CompoundPacket
+	assert.NoError(t, decoded.Unmarshal(data))
+
+	got := GatherReports(decoded)
+	assert.Len(t, got, 1)
+	assert.Equal(t, blocks, got[reporter])
+
+	other := &ReceiverReport{SSRC: 0x1234, Reports: blocks[:1]}
+	got = GatherReports(append(decoded, other))
+	assert.Len(t, got, 2)
+	assert.Equal(t, blocks[:1], got[0x1234])
+}
+
+func TestCompoundPacketLeadingReport(t *testing.T) {
+	const ssrc = 0x902f9e2e
+	reports := []ReceptionReport{{SSRC: 0xbc5e9a40, LastSequenceNumber: 0x46e1}}
+
+	for _, test := range []struct {
+		Name    string
+		Leading Packet
+	}{
+		{
+			Name:    "SR from an endpoint that sends media",
+			Leading: &SenderReport{SSRC: ssrc, NTPTime: 0xda8bd1fcdddda05a, Reports: reports},
+		},
+		{
+			Name:    "RR from a receive-only endpoint",
+			Leading: &ReceiverReport{SSRC: ssrc, Reports: reports},
+		},
+	} {
+		c := CompoundPacket{
+			test.Leading,
+			&ReceiverReport{SSRC: ssrc},
+			NewCNAMESourceDescription(ssrc, "cname"),
+			&PictureLossIndication{SenderSSRC: ssrc, MediaSSRC: 0xbc5e9a40},
+		}
+		assert.NoError(t, c.Validate(), test.Name)
+
+		data, err := c.Marshal()
+		assert.NoError(t, err, test.Name)
+
+		var decoded CompoundPacket
+		assert.NoError(t, decoded.Unmarshal(data), test.Name)
+		assert.Len(t, decoded, 4, test.Name)
+		assert.IsType(t, test.Leading, decoded[0], test.Name)
+		assert.Equal(t, reports, GatherReports(decoded)[ssrc], test.Name)
+	}
+}
+
+func TestCompoundPacketAllSSRCs(t *testing.T) {
+	c := CompoundPacket{
+		&ReceiverReport{
+			SSRC:    0x1,
+			Reports: []ReceptionReport{{SSRC: 0x10}, {SSRC: 0x20}},
+		},
+		NewCNAMESourceDescription(0x1, "cname"),
+		&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0x20},
+		&TransportLayerNack{SenderSSRC: 0x2, MediaSSRC: 0x30},
+		&ReceiverEstimatedMaximumBitrate{SenderSSRC: 0x1, Bitrate: 1000, SSRCs: []uint32{0x10, 0x40}},
+		&Goodbye{Sources: []uint32{0x30, 0x50}},
+	}
+
+	assert.Equal(t, []uint32{0x1, 0x10, 0x20, 0x2, 0x30, 0x40, 0x50}, c.AllSSRCs())
+	assert.Empty(t, CompoundPacket{}.AllSSRCs())
+}
@@ -0,0 +1,75 @@
+package rtcp
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// DuplicateFilter detects datagrams that were delivered more than once by
+// remembering a hash of each of the most recent datagrams it has seen.
+// It looks only at the bytes, so it works with any transport. Two distinct
+// datagrams colliding on the 64-bit hash would wrongly be flagged, which
+// is negligible at realistic window sizes.
+//
+// The zero value has an empty window and reports nothing as a duplicate.
+// A DuplicateFilter is safe for concurrent use.
+type DuplicateFilter struct {
+	mu     sync.Mutex
+	window []uint64
+	next   int
+	counts map[uint64]int
+}
+
+// NewDuplicateFilter returns a DuplicateFilter that remembers the last
+// size datagrams. A size of 0 or less gives an empty window.
+func NewDuplicateFilter(size int) *DuplicateFilter {
+	if size < 0 {
+		size = 0
+	}
+	return &DuplicateFilter{
+		window: make([]uint64, 0, size),
+		counts: make(map[uint64]int, size),
+	}
+}
+
+// Seen reports whether rawData is identical to one of the datagrams in the
+// window, and then adds it to the window, evicting the oldest datagram if
+// the window is full. With an empty window Seen always returns false.
+func (f *DuplicateFilter) Seen(rawData []byte) bool {
+	h := fnv.New64a()
+	_, _ = h.Write(rawData)
+	sum := h.Sum64()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if cap(f.window) == 0 {
+		return false
+	}
+	seen := f.counts[sum] > 0
+
+	if len(f.window) < cap(f.window) {
+		f.window = append(f.window, sum)
+	} else {
+		old := f.window[f.next]
+		if f.counts[old]--; f.counts[old] == 0 {
+			delete(f.counts, old)
+		}
+		f.window[f.next] = sum
+		f.next = (f.next + 1) % len(f.window)
+	}
+	f.counts[sum]++
+
+	return seen
+}
+
+// Reset forgets every datagram in the window, so that none of them is
+// reported as a duplicate. The window size is kept.
+func (f *DuplicateFilter) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.window = f.window[:0]
+	f.next = 0
+	f.counts = make(map[uint64]int, cap(f.window))
+}
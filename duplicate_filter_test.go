@@ -0,0 +1,62 @@
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateFilter(t *testing.T) {
+	f := NewDuplicateFilter(2)
+
+	pli, err := (&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}).Marshal()
+	assert.NoError(t, err)
+	rr, err := (&ReceiverReport{SSRC: 1}).Marshal()
+	assert.NoError(t, err)
+	bye, err := (&Goodbye{Sources: []uint32{1}}).Marshal()
+	assert.NoError(t, err)
+
+	assert.False(t, f.Seen(pli))
+	assert.True(t, f.Seen(pli), "second delivery should be flagged")
+	assert.False(t, f.Seen(rr))
+
+	// The window holds two datagrams, so both PLIs are evicted once the
+	// RR and the BYE have been seen.
+	assert.False(t, f.Seen(bye))
+	assert.True(t, f.Seen(rr))
+	assert.False(t, f.Seen(pli))
+}
+
+func TestDuplicateFilterReset(t *testing.T) {
+	f := NewDuplicateFilter(2)
+
+	pli, err := (&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}).Marshal()
+	assert.NoError(t, err)
+	rr, err := (&ReceiverReport{SSRC: 1}).Marshal()
+	assert.NoError(t, err)
+	bye, err := (&Goodbye{Sources: []uint32{1}}).Marshal()
+	assert.NoError(t, err)
+
+	assert.False(t, f.Seen(pli))
+	assert.False(t, f.Seen(rr))
+	f.Reset()
+
+	// Behaves like a fresh filter, including eviction once full.
+	assert.False(t, f.Seen(pli))
+	assert.True(t, f.Seen(pli))
+	assert.False(t, f.Seen(rr))
+	assert.False(t, f.Seen(bye))
+	assert.False(t, f.Seen(pli))
+}
+
+func TestDuplicateFilterEmptyWindow(t *testing.T) {
+	pli, err := (&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}).Marshal()
+	assert.NoError(t, err)
+
+	for _, f := range []*DuplicateFilter{NewDuplicateFilter(0), NewDuplicateFilter(-1), {}} {
+		assert.False(t, f.Seen(pli))
+		assert.False(t, f.Seen(pli))
+		f.Reset()
+		assert.False(t, f.Seen(pli))
+	}
+}
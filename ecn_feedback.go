@@ -0,0 +1,150 @@
+package rtcp
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// The ECNFeedback packet reports the Explicit Congestion Notification
+// markings of the RTP packets received from a media source, as defined in
+// RFC 6679 section 5.1. The counters are cumulative since the receiver
+// started receiving the source.
+type ECNFeedback struct {
+	// SSRC of sender
+	SenderSSRC uint32
+
+	// SSRC of the media source
+	MediaSSRC uint32
+
+	// The highest sequence number received, extended with the count of
+	// sequence number cycles as in a ReceptionReport
+	ExtendedHighestSequenceNumber uint32
+
+	// Number of packets received with the ECT(0) codepoint
+	ECT0Counter uint32
+
+	// Number of packets received with the ECT(1) codepoint
+	ECT1Counter uint32
+
+	// Number of packets received with the ECN-CE codepoint
+	ECNCECounter uint16
+
+	// Number of packets received with the not-ECT codepoint
+	NotECTCounter uint16
+
+	// Number of packets expected but not received
+	LostPacketsCounter uint16
+
+	// Number of packets received more than once
+	DuplicationCounter uint16
+}
+
+const (
+	ecnLength = 7
+
+	ecnMediaOffset        = 4
+	ecnSequenceOffset     = 8
+	ecnECT0Offset         = 12
+	ecnECT1Offset         = 16
+	ecnECNCEOffset        = 20
+	ecnNotECTOffset       = 22
+	ecnLostOffset         = 24
+	ecnDuplicationOffset  = 26
+	ecnFeedbackBodyLength = 28
+)
+
+// Marshal encodes the ECNFeedback in binary
+func (p ECNFeedback) Marshal() ([]byte, error) {
+	/*
+	 *  0                   1                   2                   3
+	 *  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * | Extended Highest Sequence Number                              |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * | ECT (0) Counter                                               |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * | ECT (1) Counter                                               |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * | ECN-CE Counter                | not-ECT Counter               |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * | Lost Packets Counter          | Duplication Counter           |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 */
+	rawPacket := make([]byte, p.len())
+	packetBody := rawPacket[headerLength:]
+
+	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
+	binary.BigEndian.PutUint32(packetBody[ecnMediaOffset:], p.MediaSSRC)
+	binary.BigEndian.PutUint32(packetBody[ecnSequenceOffset:], p.ExtendedHighestSequenceNumber)
+	binary.BigEndian.PutUint32(packetBody[ecnECT0Offset:], p.ECT0Counter)
+	binary.BigEndian.PutUint32(packetBody[ecnECT1Offset:], p.ECT1Counter)
+	binary.BigEndian.PutUint16(packetBody[ecnECNCEOffset:], p.ECNCECounter)
+	binary.BigEndian.PutUint16(packetBody[ecnNotECTOffset:], p.NotECTCounter)
+	binary.BigEndian.PutUint16(packetBody[ecnLostOffset:], p.LostPacketsCounter)
+	binary.BigEndian.PutUint16(packetBody[ecnDuplicationOffset:], p.DuplicationCounter)
+
+	hData, err := p.Header().Marshal()
+	if err != nil {
+		return nil, err
+	}
+	copy(rawPacket, hData)
+
+	return rawPacket, nil
+}
+
+// Unmarshal decodes the ECNFeedback from binary
+func (p *ECNFeedback) Unmarshal(rawPacket []byte) error {
+	if len(rawPacket) < headerLength+ecnFeedbackBodyLength {
+		return errPacketTooShort
+	}
+
+	var h Header
+	if err := h.Unmarshal(rawPacket); err != nil {
+		return err
+	}
+
+	if h.Type != TypeTransportSpecificFeedback || h.Count != FormatECN {
+		return errWrongType
+	}
+
+	packetBody := rawPacket[headerLength:]
+	p.SenderSSRC = binary.BigEndian.Uint32(packetBody)
+	p.MediaSSRC = binary.BigEndian.Uint32(packetBody[ecnMediaOffset:])
+	p.ExtendedHighestSequenceNumber = binary.BigEndian.Uint32(packetBody[ecnSequenceOffset:])
+	p.ECT0Counter = binary.BigEndian.Uint32(packetBody[ecnECT0Offset:])
+	p.ECT1Counter = binary.BigEndian.Uint32(packetBody[ecnECT1Offset:])
+	p.ECNCECounter = binary.BigEndian.Uint16(packetBody[ecnECNCEOffset:])
+	p.NotECTCounter = binary.BigEndian.Uint16(packetBody[ecnNotECTOffset:])
+	p.LostPacketsCounter = binary.BigEndian.Uint16(packetBody[ecnLostOffset:])
+	p.DuplicationCounter = binary.BigEndian.Uint16(packetBody[ecnDuplicationOffset:])
+	return nil
+}
+
+func (p *ECNFeedback) len() int {
+	return headerLength + ecnFeedbackBodyLength
+}
+
+// Header returns the Header associated with this packet.
+func (p *ECNFeedback) Header() Header {
+	return Header{
+		Count:  FormatECN,
+		Type:   TypeTransportSpecificFeedback,
+		Length: ecnLength,
+	}
+}
+
+// Reset clears the ECNFeedback so it can be reused.
+func (p *ECNFeedback) Reset() {
+	*p = ECNFeedback{}
+}
+
+// DestinationSSRC returns an array of SSRC values that this packet refers to.
+func (p *ECNFeedback) DestinationSSRC() []uint32 {
+	return []uint32{p.MediaSSRC}
+}
+
+func (p *ECNFeedback) String() string {
+	return fmt.Sprintf("ECNFeedback %x %x ext seq %d ECT(0) %d ECT(1) %d ECN-CE %d not-ECT %d lost %d dup %d",
+		p.SenderSSRC, p.MediaSSRC, p.ExtendedHighestSequenceNumber, p.ECT0Counter, p.ECT1Counter,
+		p.ECNCECounter, p.NotECTCounter, p.LostPacketsCounter, p.DuplicationCounter)
+}
@@ -0,0 +1,127 @@
+package rtcp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var _ Packet = (*ECNFeedback)(nil) // assert is a Packet
+
+func TestECNFeedbackUnmarshal(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Want      ECNFeedback
+		WantError error
+	}{
+		{
+			Name: "valid",
+			Data: []byte{
+				// ECNFeedback, len=7
+				0x88, 0xcd, 0x0, 0x7,
+				// sender=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// media=0xbc5e9a40
+				0xbc, 0x5e, 0x9a, 0x40,
+				// extended highest sequence number=0x000146e1
+				0x0, 0x1, 0x46, 0xe1,
+				// ECT(0)=1000
+				0x0, 0x0, 0x3, 0xe8,
+				// ECT(1)=2
+				0x0, 0x0, 0x0, 0x2,
+				// ECN-CE=17, not-ECT=3
+				0x0, 0x11, 0x0, 0x3,
+				// lost=5, duplication=1
+				0x0, 0x5, 0x0, 0x1,
+			},
+			Want: ECNFeedback{
+				SenderSSRC:                    0x902f9e2e,
+				MediaSSRC:                     0xbc5e9a40,
+				ExtendedHighestSequenceNumber: 0x000146e1,
+				ECT0Counter:                   1000,
+				ECT1Counter:                   2,
+				ECNCECounter:                  17,
+				NotECTCounter:                 3,
+				LostPacketsCounter:            5,
+				DuplicationCounter:            1,
+			},
+		},
+		{
+			Name: "short report",
+			Data: []byte{
+				0x88, 0xcd, 0x0, 0x7,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0xbc, 0x5e, 0x9a, 0x40,
+				// counters missing
+			},
+			WantError: errPacketTooShort,
+		},
+		{
+			Name: "wrong type",
+			Data: []byte{
+				// RapidResynchronizationRequest padded to the ECN size
+				0x85, 0xcd, 0x0, 0x7,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0xbc, 0x5e, 0x9a, 0x40,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0,
+				0x0, 0x0, 0x0, 0x0,
+			},
+			WantError: errWrongType,
+		},
+		{
+			Name:      "nil",
+			Data:      nil,
+			WantError: errPacketTooShort,
+		},
+	} {
+		var ecn ECNFeedback
+		err := ecn.Unmarshal(test.Data)
+		if got, want := err, test.WantError; !errors.Is(got, want) {
+			t.Fatalf("Unmarshal %q ecn: err = %v, want %v", test.Name, got, want)
+		}
+		if err != nil {
+			continue
+		}
+
+		if got, want := ecn, test.Want; !reflect.DeepEqual(got, want) {
+			t.Fatalf("Unmarshal %q ecn: got %v, want %v", test.Name, got, want)
+		}
+	}
+}
+
+func TestECNFeedbackRoundTrip(t *testing.T) {
+	want := ECNFeedback{
+		SenderSSRC:                    0x902f9e2e,
+		MediaSSRC:                     0xbc5e9a40,
+		ExtendedHighestSequenceNumber: 0x000246e1,
+		ECT0Counter:                   0xffffffff,
+		ECT1Counter:                   7,
+		ECNCECounter:                  0xffff,
+		NotECTCounter:                 9,
+		LostPacketsCounter:            4,
+		DuplicationCounter:            2,
+	}
+
+	data, err := Marshal([]Packet{&want})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got, want := len(data), 32; got != want {
+		t.Fatalf("Marshal: len = %d, want %d", got, want)
+	}
+
+	packets, _, _, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got, want := packets, []Packet{&want}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ecn round trip: got %#v, want %#v", got, want)
+	}
+	if got, want := packets[0].DestinationSSRC(), []uint32{0xbc5e9a40}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("DestinationSSRC() = %v, want %v", got, want)
+	}
+}
@@ -13,14 +13,19 @@ var (
 	errTooManyReports           = errors.New("rtcp: too many reports")
 	errTooManyChunks            = errors.New("rtcp: too many chunks")
 	errTooManySources           = errors.New("rtcp: too many sources")
+	errTooManyRecvDeltas        = errors.New("rtcp: more received packets than receive deltas fit in the packet")
+	errPacketStatusCount        = errors.New("rtcp: packet status chunks do not match the packet status count")
 	errPacketTooShort           = errors.New("rtcp: packet too short")
+	errPacketTooLong            = errors.New("rtcp: packet length does not fit in the header length field")
 	errWrongType                = errors.New("rtcp: wrong packet type")
 	errSDESTextTooLong          = errors.New("rtcp: sdes must be < 255 octets long")
 	errSDESMissingType          = errors.New("rtcp: sdes item missing type")
+	errSDESZeroSource           = errors.New("rtcp: sdes chunk SSRC/CSRC must not be 0")
 	errReasonTooLong            = errors.New("rtcp: reason must be < 255 octets long")
 	errBadVersion               = errors.New("rtcp: invalid packet version")
 	errWrongPadding             = errors.New("rtcp: invalid padding value")
 	errWrongFeedbackType        = errors.New("rtcp: wrong feedback message type")
+	errReservedFMT              = errors.New("rtcp: reserved feedback message type")
 	errWrongPayloadType         = errors.New("rtcp: wrong payload type")
 	errHeaderTooSmall           = errors.New("rtcp: header length is too small")
 	errSSRCMustBeZero           = errors.New("rtcp: media SSRC must be 0")
@@ -31,4 +36,22 @@ var (
 	errWrongChunkType           = errors.New("rtcp: wrong chunk type")
 	errBadStructMemberType      = errors.New("rtcp: struct contains unexpected member type")
 	errBadReadParameter         = errors.New("rtcp: cannot read into non-pointer")
+	errInvalidAuthTagLength     = errors.New("rtcp: invalid SRTCP authentication tag length")
+	errReportSSRCMismatch       = errors.New("rtcp: reception reports are about different sources")
+	errSequenceNumberRegressed  = errors.New("rtcp: extended highest sequence number went backwards")
+	errLossExceedsExpected      = errors.New("rtcp: more packets lost than expected")
+	errNonIncreasingNTPTime     = errors.New("rtcp: NTP time did not advance between reports")
+	errInvalidClockRate         = errors.New("rtcp: RTP clock rate must not be 0")
+	errRTTUnavailable           = errors.New("rtcp: report does not allow computing a round-trip time")
+	errZeroReporterSSRC         = errors.New("rtcp: reporter SSRC must not be 0")
+	errZeroMediaSSRC            = errors.New("rtcp: media SSRC must not be 0")
+	errMisalignedPacket         = errors.New("rtcp: packet is not 32-bit aligned")
+	errTrailingData             = errors.New("rtcp: data follows the packet")
+	errReceiptTimeCountMismatch = errors.New("rtcp: receipt time count does not match sequence range")
+	errBadXRBlockLength         = errors.New("rtcp: XR block length does not fit its block type")
+	errInvalidJSONSSRC          = errors.New("rtcp: invalid SSRC in JSON")
+	errInvalidJSONNTPTime       = errors.New("rtcp: invalid NTP time in JSON")
+	errInvalidJSONSDESType      = errors.New("rtcp: invalid SDES item type in JSON")
+	errRewriteUnsupported       = errors.New("rtcp: cannot rewrite SSRCs of packet")
+	errUnmappedSSRC             = errors.New("rtcp: no mapping for SSRC")
 )
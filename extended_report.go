@@ -49,6 +49,41 @@ type XRHeader struct {
 	BlockLength  uint16
 }
 
+const xrHeaderLength = 4
+
+// setup fills in the header of a block of type t, whose marshaled size,
+// header included, is size octets.
+func (h *XRHeader) setup(t BlockTypeType, typeSpecific TypeSpecificField, size int) {
+	h.BlockType = t
+	h.TypeSpecific = typeSpecific
+	h.BlockLength = uint16(size/4 - 1)
+}
+
+// unmarshal decodes the header at the start of the report blocks in b,
+// and returns the size in octets of the whole block it starts. A block
+// must fit in b, so a crafted length cannot make the caller read past the
+// end, and its length must suit its type, as checked by
+// validXRBlockLength. A zero-length block is the 4-octet header alone,
+// such as an empty DLRR.
+func (h *XRHeader) unmarshal(b []byte) (int, error) {
+	if len(b) < xrHeaderLength {
+		return 0, errPacketTooShort
+	}
+	buffer := packetBuffer{bytes: b}
+	if err := buffer.read(h); err != nil {
+		return 0, err
+	}
+
+	blockLength := (int(h.BlockLength) + 1) * 4
+	if blockLength > len(b) {
+		return 0, errPacketTooShort
+	}
+	if !validXRBlockLength(h.BlockType, h.BlockLength) {
+		return 0, errBadXRBlockLength
+	}
+	return blockLength, nil
+}
+
 // BlockTypeType specifies the type of report in a report block
 type BlockTypeType uint8
 
@@ -112,6 +147,47 @@ type rleReportBlock struct {
 	Chunks   []Chunk
 }
 
+// flatten expands the chunks into the sequence numbers whose bit equals
+// set. Chunk bits cover, in order, every sequence number in
+// [BeginSeq, EndSeq) that is a multiple of 2^T; bits past EndSeq and
+// terminating null chunks are ignored.
+func (b *rleReportBlock) flatten(set bool) []uint16 {
+	step := 1 << (b.T & 0x0F)
+	n := int(b.EndSeq - b.BeginSeq)
+	first := (step - int(b.BeginSeq)%step) % step
+	remaining := 0
+	if first < n {
+		remaining = (n - first + step - 1) / step
+	}
+	seq := b.BeginSeq + uint16(first)
+
+	var out []uint16
+	emit := func(bit bool) {
+		if remaining == 0 {
+			return
+		}
+		if bit == set {
+			out = append(out, seq)
+		}
+		seq += uint16(step)
+		remaining--
+	}
+	for _, c := range b.Chunks {
+		switch c.Type() {
+		case RunLengthChunkType:
+			runType, _ := c.RunType()
+			for i := uint(0); i < c.Value(); i++ {
+				emit(runType == 1)
+			}
+		case BitVectorChunkType:
+			for i := 14; i >= 0; i-- {
+				emit(c&(1<<uint(i)) != 0)
+			}
+		}
+	}
+	return out
+}
+
 // Chunk as defined in RFC 3611, section 4.1. These represent information
 // about packet losses and packet duplication. They have three representations:
 //
@@ -150,17 +226,22 @@ func (b *LossRLEReportBlock) DestinationSSRC() []uint32 {
 }
 
 func (b *LossRLEReportBlock) setupBlockHeader() {
-	b.XRHeader.BlockType = LossRLEReportBlockType
-	b.XRHeader.TypeSpecific = TypeSpecificField(b.T & 0x0F)
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1)
+	b.XRHeader.setup(LossRLEReportBlockType, TypeSpecificField(b.T&0x0F), wireSize(b))
 }
 
 func (b *LossRLEReportBlock) unpackBlockHeader() {
 	b.T = uint8(b.XRHeader.TypeSpecific) & 0x0F
 }
 
+// Flatten returns the sequence numbers the block reports as lost. In a
+// Loss RLE block a zero bit or run type means the packet was not
+// received.
+func (b *LossRLEReportBlock) Flatten() []uint16 {
+	return (*rleReportBlock)(b).flatten(false)
+}
+
 // DuplicateRLEReportBlock is used to report information about packet
-// duplication, as described in RFC 3611, section 4.1
+// duplication, as described in RFC 3611, section 4.2
 type DuplicateRLEReportBlock rleReportBlock
 
 // DestinationSSRC returns an array of SSRC values that this report block refers to.
@@ -169,15 +250,20 @@ func (b *DuplicateRLEReportBlock) DestinationSSRC() []uint32 {
 }
 
 func (b *DuplicateRLEReportBlock) setupBlockHeader() {
-	b.XRHeader.BlockType = DuplicateRLEReportBlockType
-	b.XRHeader.TypeSpecific = TypeSpecificField(b.T & 0x0F)
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1)
+	b.XRHeader.setup(DuplicateRLEReportBlockType, TypeSpecificField(b.T&0x0F), wireSize(b))
 }
 
 func (b *DuplicateRLEReportBlock) unpackBlockHeader() {
 	b.T = uint8(b.XRHeader.TypeSpecific) & 0x0F
 }
 
+// Flatten returns the sequence numbers the block reports as duplicated.
+// In a Duplicate RLE block a one bit or run type means the packet was
+// received more than once.
+func (b *DuplicateRLEReportBlock) Flatten() []uint16 {
+	return (*rleReportBlock)(b).flatten(true)
+}
+
 // ChunkType enumerates the three kinds of chunks described in RFC 3611 section 4.1.
 type ChunkType uint8
 
@@ -266,15 +352,26 @@ func (b *PacketReceiptTimesReportBlock) DestinationSSRC() []uint32 {
 }
 
 func (b *PacketReceiptTimesReportBlock) setupBlockHeader() {
-	b.XRHeader.BlockType = PacketReceiptTimesReportBlockType
-	b.XRHeader.TypeSpecific = TypeSpecificField(b.T & 0x0F)
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1)
+	b.XRHeader.setup(PacketReceiptTimesReportBlockType, TypeSpecificField(b.T&0x0F), wireSize(b))
 }
 
 func (b *PacketReceiptTimesReportBlock) unpackBlockHeader() {
 	b.T = uint8(b.XRHeader.TypeSpecific) & 0x0F
 }
 
+// receiptTimeCount returns the number of receipt times the block must
+// carry: one for every sequence number in [BeginSeq, EndSeq) that is a
+// multiple of 2^T, as described in RFC 3611 section 4.1.
+func (b *PacketReceiptTimesReportBlock) receiptTimeCount() int {
+	step := 1 << (b.T & 0x0F)
+	n := int(b.EndSeq - b.BeginSeq)
+	first := (step - int(b.BeginSeq)%step) % step
+	if first >= n {
+		return 0
+	}
+	return (n - first + step - 1) / step
+}
+
 // ReceiverReferenceTimeReportBlock encodes a Receiver Reference Time
 // report block as described in RFC 3611 section 4.4.
 //
@@ -298,9 +395,7 @@ func (b *ReceiverReferenceTimeReportBlock) DestinationSSRC() []uint32 {
 }
 
 func (b *ReceiverReferenceTimeReportBlock) setupBlockHeader() {
-	b.XRHeader.BlockType = ReceiverReferenceTimeReportBlockType
-	b.XRHeader.TypeSpecific = 0
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1)
+	b.XRHeader.setup(ReceiverReferenceTimeReportBlockType, 0, wireSize(b))
 }
 
 func (b *ReceiverReferenceTimeReportBlock) unpackBlockHeader() {
@@ -346,9 +441,7 @@ func (b *DLRRReportBlock) DestinationSSRC() []uint32 {
 }
 
 func (b *DLRRReportBlock) setupBlockHeader() {
-	b.XRHeader.BlockType = DLRRReportBlockType
-	b.XRHeader.TypeSpecific = 0
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1)
+	b.XRHeader.setup(DLRRReportBlockType, 0, wireSize(b))
 }
 
 func (b *DLRRReportBlock) unpackBlockHeader() {
@@ -430,19 +523,18 @@ func (b *StatisticsSummaryReportBlock) DestinationSSRC() []uint32 {
 }
 
 func (b *StatisticsSummaryReportBlock) setupBlockHeader() {
-	b.XRHeader.BlockType = StatisticsSummaryReportBlockType
-	b.XRHeader.TypeSpecific = 0x00
+	typeSpecific := TypeSpecificField(0x00)
 	if b.LossReports {
-		b.XRHeader.TypeSpecific |= 0x80
+		typeSpecific |= 0x80
 	}
 	if b.DuplicateReports {
-		b.XRHeader.TypeSpecific |= 0x40
+		typeSpecific |= 0x40
 	}
 	if b.JitterReports {
-		b.XRHeader.TypeSpecific |= 0x20
+		typeSpecific |= 0x20
 	}
-	b.XRHeader.TypeSpecific |= TypeSpecificField((b.TTLorHopLimit & 0x03) << 3)
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1)
+	typeSpecific |= TypeSpecificField((b.TTLorHopLimit & 0x03) << 3)
+	b.XRHeader.setup(StatisticsSummaryReportBlockType, typeSpecific, wireSize(b))
 }
 
 func (b *StatisticsSummaryReportBlock) unpackBlockHeader() {
@@ -508,9 +600,7 @@ func (b *VoIPMetricsReportBlock) DestinationSSRC() []uint32 {
 }
 
 func (b *VoIPMetricsReportBlock) setupBlockHeader() {
-	b.XRHeader.BlockType = VoIPMetricsReportBlockType
-	b.XRHeader.TypeSpecific = 0
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1)
+	b.XRHeader.setup(VoIPMetricsReportBlockType, 0, wireSize(b))
 }
 
 func (b *VoIPMetricsReportBlock) unpackBlockHeader() {
@@ -529,7 +619,7 @@ func (b *UnknownReportBlock) DestinationSSRC() []uint32 {
 }
 
 func (b *UnknownReportBlock) setupBlockHeader() {
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1)
+	b.XRHeader.setup(b.BlockType, b.TypeSpecific, wireSize(b))
 }
 
 func (b *UnknownReportBlock) unpackBlockHeader() {
@@ -538,10 +628,16 @@ func (b *UnknownReportBlock) unpackBlockHeader() {
 // Marshal encodes the ExtendedReport in binary
 func (x ExtendedReport) Marshal() ([]byte, error) {
 	for _, p := range x.Reports {
+		if b, ok := p.(*PacketReceiptTimesReportBlock); ok && len(b.ReceiptTime) != b.receiptTimeCount() {
+			return []byte{}, errReceiptTimeCountMismatch
+		}
 		p.setupBlockHeader()
 	}
 
 	length := wireSize(x)
+	if err := checkPacketLength(headerLength + length); err != nil {
+		return []byte{}, err
+	}
 
 	// RTCP Header
 	header := Header{
@@ -579,7 +675,12 @@ func (x *ExtendedReport) Unmarshal(b []byte) error {
 		return errWrongType
 	}
 
-	buffer := packetBuffer{bytes: b[headerLength:]}
+	packetLength := (int(header.Length) + 1) * 4
+	if packetLength < headerLength+ssrcLength || len(b) < packetLength {
+		return errPacketTooShort
+	}
+
+	buffer := packetBuffer{bytes: b[headerLength:packetLength]}
 	err := buffer.read(&x.SenderSSRC)
 	if err != nil {
 		return err
@@ -588,9 +689,8 @@ func (x *ExtendedReport) Unmarshal(b []byte) error {
 	for len(buffer.bytes) > 0 {
 		var block ReportBlock
 
-		headerBuffer := buffer
 		xrHeader := XRHeader{}
-		err = headerBuffer.read(&xrHeader)
+		blockLength, err := xrHeader.unmarshal(buffer.bytes)
 		if err != nil {
 			return err
 		}
@@ -616,7 +716,6 @@ func (x *ExtendedReport) Unmarshal(b []byte) error {
 
 		// We need to limit the amount of data available to
 		// this block to the actual length of the block
-		blockLength := (int(xrHeader.BlockLength) + 1) * 4
 		blockBuffer := buffer.split(blockLength)
 		err = blockBuffer.read(block)
 		if err != nil {
@@ -629,6 +728,38 @@ func (x *ExtendedReport) Unmarshal(b []byte) error {
 	return nil
 }
 
+// validXRBlockLength reports whether a block of type t may declare the
+// block length length, in 32-bit words minus one, per RFC 3611 section 4.
+// Blocks with fixed contents must have exactly their size, and the others
+// must at least hold their fixed fields; unknown block types may have any
+// length.
+func validXRBlockLength(t BlockTypeType, length uint16) bool {
+	switch t {
+	case LossRLEReportBlockType, DuplicateRLEReportBlockType, PacketReceiptTimesReportBlockType:
+		// SSRC, begin_seq and end_seq
+		return length >= 2
+	case ReceiverReferenceTimeReportBlockType:
+		return length == 2
+	case DLRRReportBlockType:
+		// three words per sub-block
+		return length%3 == 0
+	case StatisticsSummaryReportBlockType:
+		return length == 9
+	case VoIPMetricsReportBlockType:
+		return length == 8
+	}
+	return true
+}
+
+// Reset clears the ExtendedReport so it can be reused, keeping the
+// capacity of its Reports slice.
+func (x *ExtendedReport) Reset() {
+	for i := range x.Reports {
+		x.Reports[i] = nil
+	}
+	*x = ExtendedReport{Reports: x.Reports[:0]}
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (x *ExtendedReport) DestinationSSRC() []uint32 {
 	ssrc := make([]uint32, 0)
@@ -1,6 +1,8 @@
 package rtcp
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -62,7 +64,7 @@ func testPacket() Packet {
 				T:        3,
 				SSRC:     0x98765432,
 				BeginSeq: 15432,
-				EndSeq:   15577,
+				EndSeq:   15470,
 				ReceiptTime: []uint32{
 					0x11111111,
 					0x22222222,
@@ -158,7 +160,7 @@ func encodedPacket() []byte {
 		// Source SSRC
 		0x98, 0x76, 0x54, 0x32,
 		// Begin & End Seq
-		0x3C, 0x48, 0x3C, 0xD9, // byte 56 - 59
+		0x3C, 0x48, 0x3C, 0x6E, // byte 56 - 59
 		// Receipt times
 		0x11, 0x11, 0x11, 0x11,
 		0x22, 0x22, 0x22, 0x22, // byte 64 - 67
@@ -260,3 +262,261 @@ func TestDecode(t *testing.T) {
 		t.Errorf("(string compare) Decoded packet does not match expected packet")
 	}
 }
+
+func TestPacketReceiptTimesRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		Name     string
+		T        uint8
+		BeginSeq uint16
+		EndSeq   uint16
+		Count    int
+	}{
+		{"every packet", 0, 100, 104, 4},
+		{"thinned", 2, 101, 110, 2},
+		{"wraparound", 1, 65533, 3, 3},
+		{"empty range", 0, 7, 7, 0},
+	} {
+		block := &PacketReceiptTimesReportBlock{
+			T:        test.T,
+			SSRC:     0x98765432,
+			BeginSeq: test.BeginSeq,
+			EndSeq:   test.EndSeq,
+		}
+		for i := 0; i < test.Count; i++ {
+			block.ReceiptTime = append(block.ReceiptTime, uint32(i+1))
+		}
+		rawPacket, err := (&ExtendedReport{SenderSSRC: 1, Reports: []ReportBlock{block}}).Marshal()
+		if err != nil {
+			t.Fatalf("%s: Marshal err = %v", test.Name, err)
+		}
+
+		var decoded ExtendedReport
+		if err := decoded.Unmarshal(rawPacket); err != nil {
+			t.Fatalf("%s: Unmarshal err = %v", test.Name, err)
+		}
+		if len(decoded.Reports) != 1 {
+			t.Fatalf("%s: got %d blocks, want 1", test.Name, len(decoded.Reports))
+		}
+		if !reflect.DeepEqual(decoded.Reports[0], block) {
+			t.Errorf("%s: decoded %v, want %v", test.Name, decoded.Reports[0], block)
+		}
+
+		block.ReceiptTime = append(block.ReceiptTime, 0)
+		if _, err := (&ExtendedReport{Reports: []ReportBlock{block}}).Marshal(); !errors.Is(err, errReceiptTimeCountMismatch) {
+			t.Errorf("%s: Marshal with extra receipt time err = %v, want %v", test.Name, err, errReceiptTimeCountMismatch)
+		}
+	}
+}
+
+func TestDecodeBadBlockLength(t *testing.T) {
+	for _, test := range []struct {
+		Name string
+		Data []byte
+	}{
+		{
+			Name: "block longer than packet",
+			Data: []byte{
+				0x80, 0xCF, 0x00, 0x03,
+				0x01, 0x02, 0x03, 0x04,
+				0x04, 0x00, 0x00, 0xFF,
+				0x01, 0x02, 0x03, 0x04,
+			},
+		},
+		{
+			Name: "packet longer than buffer",
+			Data: []byte{
+				0x80, 0xCF, 0x00, 0x09,
+				0x01, 0x02, 0x03, 0x04,
+			},
+		},
+	} {
+		var p ExtendedReport
+		if err := p.Unmarshal(test.Data); !errors.Is(err, errPacketTooShort) {
+			t.Errorf("%s: Unmarshal err = %v, want %v", test.Name, err, errPacketTooShort)
+		}
+	}
+}
+
+func TestDecodeEmptyDLRR(t *testing.T) {
+	data := []byte{
+		0x80, 0xCF, 0x00, 0x02,
+		0x00, 0x00, 0x00, 0x01,
+		// DLRR with no sub-blocks
+		0x05, 0x00, 0x00, 0x00,
+	}
+	want := &ExtendedReport{
+		SenderSSRC: 1,
+		Reports: []ReportBlock{
+			&DLRRReportBlock{
+				XRHeader: XRHeader{BlockType: DLRRReportBlockType},
+			},
+		},
+	}
+
+	out, err := want.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+	if !bytes.Equal(out, data) {
+		t.Fatalf("Marshal = %x, want %x", out, data)
+	}
+
+	var p ExtendedReport
+	if err := p.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal err = %v", err)
+	}
+	if got := p.Reports; len(got) != 1 || len(got[0].(*DLRRReportBlock).Reports) != 0 {
+		t.Fatalf("Reports = %v, want one empty DLRR block", got)
+	}
+}
+
+func TestDecodeMismatchedBlockLength(t *testing.T) {
+	for _, test := range []struct {
+		Name string
+		Data []byte
+	}{
+		{
+			Name: "DLRR block too small for its sub-block",
+			Data: []byte{
+				0x80, 0xCF, 0x00, 0x06,
+				0x01, 0x02, 0x03, 0x04,
+				// DLRR, block length 2 instead of 3
+				0x05, 0x00, 0x00, 0x02,
+				0x88, 0x88, 0x88, 0x88,
+				0x12, 0x34, 0x56, 0x78,
+				// the last word of the sub-block, read as the
+				// header of the next block
+				0x04, 0x00, 0x00, 0x02,
+				0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			Name: "receiver reference time block too small",
+			Data: []byte{
+				0x80, 0xCF, 0x00, 0x03,
+				0x01, 0x02, 0x03, 0x04,
+				0x04, 0x00, 0x00, 0x01,
+				0x01, 0x02, 0x03, 0x04,
+			},
+		},
+		{
+			Name: "statistics summary block too large",
+			Data: append([]byte{
+				0x80, 0xCF, 0x00, 0x0C,
+				0x01, 0x02, 0x03, 0x04,
+				0x06, 0x00, 0x00, 0x0A,
+			}, make([]byte, 40)...),
+		},
+	} {
+		var p ExtendedReport
+		if err := p.Unmarshal(test.Data); !errors.Is(err, errBadXRBlockLength) {
+			t.Errorf("%s: Unmarshal err = %v, want %v", test.Name, err, errBadXRBlockLength)
+		}
+	}
+}
+
+func TestDecodeLossAndDuplicateRLE(t *testing.T) {
+	rawPacket, err := (&ExtendedReport{
+		SenderSSRC: 0x01020304,
+		Reports: []ReportBlock{
+			&LossRLEReportBlock{
+				SSRC:     0x12345678,
+				BeginSeq: 100,
+				EndSeq:   120,
+				Chunks: []Chunk{
+					0x4003, // run of 3 received
+					0x0002, // run of 2 lost
+					0xC000, // bit vector, only the first packet received
+					0x0000, // terminating null
+				},
+			},
+			&DuplicateRLEReportBlock{
+				SSRC:     0x12345678,
+				BeginSeq: 100,
+				EndSeq:   120,
+				Chunks: []Chunk{
+					0x4003, // run of 3 duplicated
+					0x0002, // run of 2 not duplicated
+					0xC000, // bit vector, only the first packet duplicated
+					0x0000, // terminating null
+				},
+			},
+		},
+	}).Marshal()
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+
+	var decoded ExtendedReport
+	if err := decoded.Unmarshal(rawPacket); err != nil {
+		t.Fatalf("Unmarshal err = %v", err)
+	}
+	if len(decoded.Reports) != 2 {
+		t.Fatalf("got %d blocks, want 2", len(decoded.Reports))
+	}
+
+	loss, ok := decoded.Reports[0].(*LossRLEReportBlock)
+	if !ok {
+		t.Fatalf("block 0 is %T, want *LossRLEReportBlock", decoded.Reports[0])
+	}
+	dup, ok := decoded.Reports[1].(*DuplicateRLEReportBlock)
+	if !ok {
+		t.Fatalf("block 1 is %T, want *DuplicateRLEReportBlock", decoded.Reports[1])
+	}
+
+	// The bit vector covers 105-119, and 0xC000 has only the chunk type
+	// bit and the bit for 105 set.
+	wantLost := []uint16{103, 104, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119}
+	if got := loss.Flatten(); !reflect.DeepEqual(got, wantLost) {
+		t.Errorf("LossRLE Flatten = %v, want %v", got, wantLost)
+	}
+	if got, want := dup.Flatten(), []uint16{100, 101, 102, 105}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DuplicateRLE Flatten = %v, want %v", got, want)
+	}
+}
+
+func TestXRHeaderUnmarshal(t *testing.T) {
+	for _, test := range []struct {
+		Name  string
+		Block ReportBlock
+		Want  XRHeader
+	}{
+		{
+			Name: "DLRR",
+			Block: &DLRRReportBlock{
+				Reports: []DLRRReport{
+					{SSRC: 0x88888888, LastRR: 0x12345678, DLRR: 0x99999999},
+					{SSRC: 0x09090909, LastRR: 0x12345678, DLRR: 0x99999999},
+				},
+			},
+			Want: XRHeader{BlockType: DLRRReportBlockType, BlockLength: 6},
+		},
+		{
+			Name:  "VoIP metrics",
+			Block: &VoIPMetricsReportBlock{SSRC: 0x89ABCDEF, LossRate: 0x05, RXConfig: 0x3F},
+			Want:  XRHeader{BlockType: VoIPMetricsReportBlockType, BlockLength: 8},
+		},
+	} {
+		data, err := ExtendedReport{SenderSSRC: 0x01020304, Reports: []ReportBlock{test.Block}}.Marshal()
+		if err != nil {
+			t.Fatalf("%s: Marshal err = %v", test.Name, err)
+		}
+		blocks := data[headerLength+ssrcLength:]
+
+		var h XRHeader
+		size, err := h.unmarshal(blocks)
+		if err != nil {
+			t.Fatalf("%s: unmarshal err = %v", test.Name, err)
+		}
+		if h != test.Want {
+			t.Errorf("%s: header = %+v, want %+v", test.Name, h, test.Want)
+		}
+		if size != len(blocks) {
+			t.Errorf("%s: block size = %d, want %d", test.Name, size, len(blocks))
+		}
+
+		if _, err := h.unmarshal(blocks[:size-4]); !errors.Is(err, errPacketTooShort) {
+			t.Errorf("%s: truncated unmarshal err = %v, want %v", test.Name, err, errPacketTooShort)
+		}
+	}
+}
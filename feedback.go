@@ -0,0 +1,198 @@
+package rtcp
+
+import "encoding/binary"
+
+// FeedbackTargets returns the media SSRCs that a feedback packet (RFC 4585
+// transport-layer or payload-specific feedback, including TWCC, REMB and
+// RFC 8888 congestion control feedback) asks the sender to act on. Unlike
+// DestinationSSRC it never includes the SSRC of the packet sender, so SFUs
+// can intersect the result with the set of SSRCs they forward. For an
+// undecoded feedback packet the media SSRC from its common header is
+// returned. Non-feedback packets have no targets and return nil.
+func FeedbackTargets(p Packet) []uint32 {
+	switch p := p.(type) {
+	case *TransportLayerNack, *RapidResynchronizationRequest, *TransportLayerCC, *ECNFeedback,
+		*PictureLossIndication, *SliceLossIndication, *FullIntraRequest,
+		*ReceiverEstimatedMaximumBitrate, *CCFeedbackReport:
+		ssrcs := p.DestinationSSRC()
+		out := make([]uint32, len(ssrcs))
+		copy(out, ssrcs)
+		return out
+	case *RawPacket:
+		return rawFeedbackTarget(*p)
+	}
+	return nil
+}
+
+// IsStandaloneFeedback peeks at the header of the datagram in rawData and
+// reports whether it is a single reduced-size RTCP packet (RFC 5506)
+// carrying transport-layer or payload-specific feedback, rather than a
+// compound packet. The type of the first packet is returned either way.
+// Only the header is decoded; an error is returned if it is invalid or
+// declares more bytes than rawData holds.
+func IsStandaloneFeedback(rawData []byte) (bool, PacketType, error) {
+	var h Header
+	if err := h.Unmarshal(rawData); err != nil {
+		return false, 0, err
+	}
+
+	n := int(h.Length+1) * 4
+	if n > len(rawData) {
+		return false, h.Type, errPacketTooShort
+	}
+
+	isFeedback := h.Type == TypeTransportSpecificFeedback || h.Type == TypePayloadSpecificFeedback
+	return isFeedback && n == len(rawData), h.Type, nil
+}
+
+// Classify partitions packets by their role in a media pipeline. Reports
+// (SR, RR and XR) describe reception quality and feed statistics, and
+// feedback (RTPFB and PSFB, including TWCC, REMB and RFC 8888 congestion
+// control feedback) asks the media sender to act. Everything else, such as
+// SDES, BYE and APP packets, is returned in other. Packets that were not
+// decoded are classified by the type in their header, and the contents of
+// a CompoundPacket are classified individually. The relative order of the
+// packets is kept within each slice.
+func Classify(packets []Packet) (reports []Packet, feedback []Packet, other []Packet) {
+	for _, p := range packets {
+		if c, ok := p.(*CompoundPacket); ok {
+			r, f, o := Classify(*c)
+			reports = append(reports, r...)
+			feedback = append(feedback, f...)
+			other = append(other, o...)
+			continue
+		}
+
+		switch classify(p) {
+		case classReport:
+			reports = append(reports, p)
+		case classFeedback:
+			feedback = append(feedback, p)
+		default:
+			other = append(other, p)
+		}
+	}
+	return reports, feedback, other
+}
+
+type packetClass int
+
+const (
+	classOther packetClass = iota
+	classReport
+	classFeedback
+)
+
+func classify(p Packet) packetClass {
+	switch p.(type) {
+	case *SenderReport, *ReceiverReport, *ExtendedReport:
+		return classReport
+	case *TransportLayerNack, *RapidResynchronizationRequest, *TransportLayerCC, *ECNFeedback,
+		*PictureLossIndication, *SliceLossIndication, *FullIntraRequest,
+		*ReceiverEstimatedMaximumBitrate, *CCFeedbackReport:
+		return classFeedback
+	}
+
+	h, ok := p.(interface{ Header() Header })
+	if !ok {
+		return classOther
+	}
+	switch h.Header().Type {
+	case TypeSenderReport, TypeReceiverReport, TypeExtendedReport:
+		return classReport
+	case TypeTransportSpecificFeedback, TypePayloadSpecificFeedback:
+		return classFeedback
+	}
+	return classOther
+}
+
+// rawFeedbackTarget returns the media SSRC of an undecoded feedback packet.
+func rawFeedbackTarget(rawPacket []byte) []uint32 {
+	var h Header
+	if err := h.Unmarshal(rawPacket); err != nil {
+		return nil
+	}
+	if h.Type != TypeTransportSpecificFeedback && h.Type != TypePayloadSpecificFeedback {
+		return nil
+	}
+	if len(rawPacket) < headerLength+ssrcLength*2 {
+		return nil
+	}
+	return []uint32{binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])}
+}
+
+// FeedbackFormatName returns the name of feedback message type fmt for the
+// feedback packet type t, as assigned in the IANA "FMT Values" registries
+// for RTPFB and PSFB, or the empty string if fmt is unassigned.
+func FeedbackFormatName(t PacketType, fmt uint8) string { //nolint:gocyclo
+	switch t {
+	case TypeTransportSpecificFeedback:
+		switch fmt {
+		case FormatTLN:
+			return "Generic NACK" // RFC 4585
+		case 3:
+			return "TMMBR" // RFC 5104
+		case 4:
+			return "TMMBN" // RFC 5104
+		case FormatRRR:
+			return "RTCP-SR-REQ" // RFC 6051
+		case 6:
+			return "RAMS" // RFC 6285
+		case 7:
+			return "TLLEI" // RFC 6642
+		case FormatECN:
+			return "RTCP-ECN-FB" // RFC 6679
+		case 9:
+			return "PAUSE-RESUME" // RFC 7728
+		case 10:
+			return "DBI" // 3GPP TS 26.114
+		case 11:
+			return "CCFB" // RFC 8888
+		case FormatTCC:
+			return "Transport-wide CC" // draft-holmer-rmcat-transport-wide-cc-extensions
+		}
+	case TypePayloadSpecificFeedback:
+		switch fmt {
+		case FormatPLI:
+			return "PLI" // RFC 4585
+		case FormatSLI:
+			return "SLI" // RFC 4585
+		case 3:
+			return "RPSI" // RFC 4585
+		case FormatFIR:
+			return "FIR" // RFC 5104
+		case 5:
+			return "TSTR" // RFC 5104
+		case 6:
+			return "TSTN" // RFC 5104
+		case 7:
+			return "VBCM" // RFC 5104
+		case 8:
+			return "PSLEI" // RFC 6642
+		case 9:
+			return "ROI" // 3GPP TS 26.114
+		case 10:
+			return "LRR" // RFC 8082
+		case FormatREMB:
+			return "AFB" // RFC 4585
+		}
+	}
+	return ""
+}
+
+// ValidateFMT checks that fmt is an assigned feedback message type for the
+// feedback packet type t. FMT 0 and 31 are reserved by RFC 4585 and return
+// errReservedFMT; other unassigned values return errWrongFeedbackType, and
+// non-feedback packet types return errWrongType.
+func ValidateFMT(t PacketType, fmt uint8) error {
+	if t != TypeTransportSpecificFeedback && t != TypePayloadSpecificFeedback {
+		return errWrongType
+	}
+	if fmt == 0 || fmt == countMax {
+		return errReservedFMT
+	}
+	if FeedbackFormatName(t, fmt) == "" {
+		return errWrongFeedbackType
+	}
+	return nil
+}
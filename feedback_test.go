@@ -0,0 +1,166 @@
+package rtcp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackTargets(t *testing.T) {
+	for _, test := range []struct {
+		Name   string
+		Packet Packet
+		Want   []uint32
+	}{
+		{
+			Name: "nack",
+			Packet: &TransportLayerNack{
+				SenderSSRC: 0x902f9e2e,
+				MediaSSRC:  0x4bc4fcb4,
+				Nacks:      []NackPair{{PacketID: 100}},
+			},
+			Want: []uint32{0x4bc4fcb4},
+		},
+		{
+			Name:   "pli",
+			Packet: &PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x4bc4fcb4},
+			Want:   []uint32{0x4bc4fcb4},
+		},
+		{
+			Name: "fir",
+			Packet: &FullIntraRequest{
+				SenderSSRC: 0x902f9e2e,
+				FIR: []FIREntry{
+					{SSRC: 0x12345678, SequenceNumber: 1},
+					{SSRC: 0x98765432, SequenceNumber: 2},
+				},
+			},
+			Want: []uint32{0x12345678, 0x98765432},
+		},
+		{
+			Name:   "raw feedback",
+			Packet: &RawPacket{0x89, 0xcd, 0x00, 0x02, 0x90, 0x2f, 0x9e, 0x2e, 0x4b, 0xc4, 0xfc, 0xb4},
+			Want:   []uint32{0x4bc4fcb4},
+		},
+		{
+			Name: "sender report",
+			Packet: &SenderReport{
+				SSRC:    0x902f9e2e,
+				Reports: []ReceptionReport{{SSRC: 0x4bc4fcb4}},
+			},
+		},
+		{
+			Name:   "raw app",
+			Packet: &RawPacket{0x80, 0xcc, 0x00, 0x02, 0x90, 0x2f, 0x9e, 0x2e, 0x4b, 0xc4, 0xfc, 0xb4},
+		},
+	} {
+		assert.Equal(t, test.Want, FeedbackTargets(test.Packet), test.Name)
+	}
+}
+
+func TestValidateFMT(t *testing.T) {
+	for _, test := range []struct {
+		Type      PacketType
+		FMT       uint8
+		WantError error
+	}{
+		{TypeTransportSpecificFeedback, FormatTLN, nil},
+		{TypeTransportSpecificFeedback, FormatRRR, nil},
+		{TypeTransportSpecificFeedback, FormatTCC, nil},
+		{TypePayloadSpecificFeedback, FormatPLI, nil},
+		{TypePayloadSpecificFeedback, FormatSLI, nil},
+		{TypePayloadSpecificFeedback, FormatFIR, nil},
+		{TypePayloadSpecificFeedback, FormatREMB, nil},
+		{TypeTransportSpecificFeedback, 0, errReservedFMT},
+		{TypePayloadSpecificFeedback, 0, errReservedFMT},
+		{TypeTransportSpecificFeedback, 31, errReservedFMT},
+		{TypeTransportSpecificFeedback, 2, errWrongFeedbackType},
+		{TypePayloadSpecificFeedback, 20, errWrongFeedbackType},
+		{TypeReceiverReport, FormatPLI, errWrongType},
+	} {
+		if got := ValidateFMT(test.Type, test.FMT); !errors.Is(got, test.WantError) {
+			t.Errorf("ValidateFMT(%s, %d) = %v, want %v", test.Type, test.FMT, got, test.WantError)
+		}
+	}
+
+	assert.Equal(t, "PLI", FeedbackFormatName(TypePayloadSpecificFeedback, FormatPLI))
+	assert.Equal(t, "Generic NACK", FeedbackFormatName(TypeTransportSpecificFeedback, FormatTLN))
+	assert.Equal(t, "", FeedbackFormatName(TypeGoodbye, 1))
+}
+
+func TestClassify(t *testing.T) {
+	data, err := Marshal([]Packet{
+		&SenderReport{SSRC: 0x902f9e2e},
+		NewCNAMESourceDescription(0x902f9e2e, "cname"),
+		&PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0xbc5e9a40},
+		&ReceiverReport{SSRC: 0x902f9e2e},
+		&TransportLayerNack{SenderSSRC: 0x902f9e2e, MediaSSRC: 0xbc5e9a40, Nacks: []NackPair{{PacketID: 1}}},
+		&ExtendedReport{SenderSSRC: 0x902f9e2e},
+		&Goodbye{Sources: []uint32{0x902f9e2e}},
+	})
+	assert.NoError(t, err)
+	data = append(data,
+		// TMMBR, an RTPFB message without a decoder
+		0x83, 0xcd, 0x00, 0x02,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0xbc, 0x5e, 0x9a, 0x40,
+		// APP
+		0x80, 0xcc, 0x00, 0x02,
+		0x90, 0x2f, 0x9e, 0x2e,
+		'T', 'E', 'S', 'T',
+	)
+
+	packets, _, _, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.Len(t, packets, 9)
+
+	reports, feedback, other := Classify(packets)
+	assert.Equal(t, []Packet{packets[0], packets[3], packets[5]}, reports)
+	assert.Equal(t, []Packet{packets[2], packets[4], packets[7]}, feedback)
+	assert.Equal(t, []Packet{packets[1], packets[6], packets[8]}, other)
+
+	compound := CompoundPacket(packets)
+	r, f, o := Classify([]Packet{&compound})
+	assert.Equal(t, reports, r)
+	assert.Equal(t, feedback, f)
+	assert.Equal(t, other, o)
+}
+
+func TestIsStandaloneFeedback(t *testing.T) {
+	nack, err := (&TransportLayerNack{
+		SenderSSRC: 0x902f9e2e,
+		MediaSSRC:  0xbc5e9a40,
+		Nacks:      []NackPair{{PacketID: 100, LostPackets: 0x0005}},
+	}).Marshal()
+	assert.NoError(t, err)
+
+	standalone, typ, err := IsStandaloneFeedback(nack)
+	assert.NoError(t, err)
+	assert.True(t, standalone)
+	assert.Equal(t, TypeTransportSpecificFeedback, typ)
+
+	compound, err := CompoundPacket{
+		&ReceiverReport{SSRC: 0x902f9e2e},
+		NewCNAMESourceDescription(0x902f9e2e, "cname"),
+		&PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0xbc5e9a40},
+	}.Marshal()
+	assert.NoError(t, err)
+
+	standalone, typ, err = IsStandaloneFeedback(compound)
+	assert.NoError(t, err)
+	assert.False(t, standalone)
+	assert.Equal(t, TypeReceiverReport, typ)
+
+	// Feedback followed by another packet is not standalone either.
+	standalone, typ, err = IsStandaloneFeedback(append(nack, nack...))
+	assert.NoError(t, err)
+	assert.False(t, standalone)
+	assert.Equal(t, TypeTransportSpecificFeedback, typ)
+
+	_, _, err = IsStandaloneFeedback(nack[:8])
+	assert.True(t, errors.Is(err, errPacketTooShort))
+
+	_, _, err = IsStandaloneFeedback(nil)
+	assert.True(t, errors.Is(err, errPacketTooShort))
+}
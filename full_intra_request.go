@@ -9,6 +9,12 @@ import (
 type FIREntry struct {
 	SSRC           uint32
 	SequenceNumber uint8
+
+	// Reserved holds the 24 reserved bits that follow the sequence number.
+	// They are kept as received, for forward compatibility, but are only
+	// sent when marshaling with MarshalOptions.PreserveReserved; otherwise
+	// they are written as zero, as RFC 5104 requires.
+	Reserved uint32
 }
 
 // The FullIntraRequest packet is used to reliably request an Intra frame
@@ -29,12 +35,23 @@ var _ Packet = (*FullIntraRequest)(nil)
 
 // Marshal encodes the FullIntraRequest
 func (p FullIntraRequest) Marshal() ([]byte, error) {
+	return p.marshal(false)
+}
+
+func (p FullIntraRequest) marshal(preserveReserved bool) ([]byte, error) {
+	if err := checkPacketLength(p.len()); err != nil {
+		return nil, err
+	}
+
 	rawPacket := make([]byte, firOffset+(len(p.FIR)*8))
 	binary.BigEndian.PutUint32(rawPacket, p.SenderSSRC)
 	binary.BigEndian.PutUint32(rawPacket[4:], p.MediaSSRC)
 	for i, fir := range p.FIR {
 		binary.BigEndian.PutUint32(rawPacket[firOffset+8*i:], fir.SSRC)
 		rawPacket[firOffset+8*i+4] = fir.SequenceNumber
+		if preserveReserved {
+			put24(rawPacket[firOffset+8*i+5:], fir.Reserved)
+		}
 	}
 	h := p.Header()
 	hData, err := h.Marshal()
@@ -64,12 +81,18 @@ func (p *FullIntraRequest) Unmarshal(rawPacket []byte) error {
 		return errWrongType
 	}
 
+	n, err := contentLength(h, rawPacket)
+	if err != nil {
+		return err
+	}
+
 	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
 	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
-	for i := headerLength + firOffset; i < (headerLength + int(h.Length*4)); i += 8 {
+	for i := headerLength + firOffset; i+8 <= n; i += 8 {
 		p.FIR = append(p.FIR, FIREntry{
-			binary.BigEndian.Uint32(rawPacket[i:]),
-			rawPacket[i+4],
+			SSRC:           binary.BigEndian.Uint32(rawPacket[i:]),
+			SequenceNumber: rawPacket[i+4],
+			Reserved:       get24(rawPacket[i+5:]),
 		})
 	}
 	return nil
@@ -97,6 +120,24 @@ func (p *FullIntraRequest) String() string {
 	return out
 }
 
+// Reset clears the FullIntraRequest so it can be reused, keeping the
+// capacity of its FIR slice.
+func (p *FullIntraRequest) Reset() {
+	*p = FullIntraRequest{FIR: p.FIR[:0]}
+}
+
+// Validate reports likely mistakes in a FullIntraRequest that Marshal
+// accepts. RFC 5104 leaves the media SSRC of a FIR unused, so it is the
+// SSRC of each FCI entry that must be set.
+func (p *FullIntraRequest) Validate() error {
+	for _, entry := range p.FIR {
+		if entry.SSRC == 0 {
+			return errZeroMediaSSRC
+		}
+	}
+	return nil
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (p *FullIntraRequest) DestinationSSRC() []uint32 {
 	ssrcs := make([]uint32, 0, len(p.FIR))
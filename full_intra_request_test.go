@@ -16,8 +16,8 @@ func TestFullIntraRequestUnmarshal(t *testing.T) {
 		{
 			Name: "valid",
 			Data: []byte{
-				// v=2, p=0, FMT=4, PSFB, len=3
-				0x84, 0xce, 0x00, 0x03,
+				// v=2, p=0, FMT=4, PSFB, len=4
+				0x84, 0xce, 0x00, 0x04,
 				// ssrc=0x0
 				0x00, 0x00, 0x00, 0x00,
 				// ssrc=0x4bc4fcb4
@@ -38,11 +38,87 @@ func TestFullIntraRequestUnmarshal(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "padded",
+			Data: []byte{
+				// v=2, p=1, FMT=4, PSFB, len=6
+				0xa4, 0xce, 0x00, 0x06,
+				// ssrc=0x0
+				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// ssrc=0x12345678
+				0x12, 0x34, 0x56, 0x78,
+				// Seqno=0x42
+				0x42, 0x00, 0x00, 0x00,
+				// 8 bytes of padding
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x08,
+			},
+			Want: FullIntraRequest{
+				SenderSSRC: 0x0,
+				MediaSSRC:  0x4bc4fcb4,
+				FIR: []FIREntry{
+					{
+						SSRC:           0x12345678,
+						SequenceNumber: 0x42,
+					},
+				},
+			},
+		},
+		{
+			Name: "padding after a partial entry",
+			Data: []byte{
+				// v=2, p=1, FMT=4, PSFB, len=5
+				0xa4, 0xce, 0x00, 0x05,
+				// ssrc=0x0
+				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// ssrc=0x12345678, then no Seqno
+				0x12, 0x34, 0x56, 0x78,
+				// 8 bytes of padding
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x08,
+			},
+			Want: FullIntraRequest{
+				SenderSSRC: 0x0,
+				MediaSSRC:  0x4bc4fcb4,
+			},
+		},
+		{
+			Name: "trailing bytes after the packet",
+			Data: []byte{
+				// v=2, p=0, FMT=4, PSFB, len=4
+				0x84, 0xce, 0x00, 0x04,
+				// ssrc=0x0
+				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// ssrc=0x12345678
+				0x12, 0x34, 0x56, 0x78,
+				// Seqno=0x42
+				0x42, 0x00, 0x00, 0x00,
+				// an empty RR that follows in the compound
+				0x80, 0xc9, 0x00, 0x01,
+				0x90, 0x2f, 0x9e, 0x2e,
+			},
+			Want: FullIntraRequest{
+				SenderSSRC: 0x0,
+				MediaSSRC:  0x4bc4fcb4,
+				FIR: []FIREntry{
+					{
+						SSRC:           0x12345678,
+						SequenceNumber: 0x42,
+					},
+				},
+			},
+		},
 		{
 			Name: "also valid",
 			Data: []byte{
-				// v=2, p=0, FMT=4, PSFB, len=3
-				0x84, 0xce, 0x00, 0x05,
+				// v=2, p=0, FMT=4, PSFB, len=6
+				0x84, 0xce, 0x00, 0x06,
 				// ssrc=0x0
 				0x00, 0x00, 0x00, 0x00,
 				// ssrc=0x4bc4fcb4
@@ -224,3 +300,85 @@ func TestFullIntraRequestUnmarshalHeader(t *testing.T) {
 		}
 	}
 }
+
+func TestFullIntraRequestDestinationSSRC(t *testing.T) {
+	p := &FullIntraRequest{
+		SenderSSRC: 0x1,
+		MediaSSRC:  0x0,
+		FIR: []FIREntry{
+			{SSRC: 0x12345678, SequenceNumber: 1},
+			{SSRC: 0x9abcdef0, SequenceNumber: 2},
+			{SSRC: 0x0fedcba9, SequenceNumber: 3},
+		},
+	}
+
+	data, err := p.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+	var decoded FullIntraRequest
+	if err := decoded.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal err = %v", err)
+	}
+
+	// The media SSRC of a FIR is unused; every FCI entry is a target.
+	want := []uint32{0x12345678, 0x9abcdef0, 0x0fedcba9}
+	if got := decoded.DestinationSSRC(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DestinationSSRC() = %x, want %x", got, want)
+	}
+}
+
+func TestFullIntraRequestValidate(t *testing.T) {
+	// The header media SSRC of a FIR is unused and may be zero.
+	valid := &FullIntraRequest{FIR: []FIREntry{{SSRC: 0x12345678, SequenceNumber: 1}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() err = %v, want nil", err)
+	}
+
+	zero := &FullIntraRequest{
+		MediaSSRC: 0x12345678,
+		FIR:       []FIREntry{{SSRC: 0x12345678}, {SSRC: 0}},
+	}
+	if got, want := zero.Validate(), errZeroMediaSSRC; !errors.Is(got, want) {
+		t.Errorf("Validate() err = %v, want %v", got, want)
+	}
+}
+
+func TestFullIntraRequestReservedBits(t *testing.T) {
+	data := []byte{
+		// v=2, p=0, FMT=4, PSFB, len=4
+		0x84, 0xce, 0x00, 0x04,
+		// ssrc=0x0
+		0x00, 0x00, 0x00, 0x00,
+		// ssrc=0x4bc4fcb4
+		0x4b, 0xc4, 0xfc, 0xb4,
+		// ssrc=0x12345678
+		0x12, 0x34, 0x56, 0x78,
+		// Seqno=0x42, reserved=0xabcdef
+		0x42, 0xab, 0xcd, 0xef,
+	}
+
+	var fir FullIntraRequest
+	if err := fir.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal err = %v", err)
+	}
+	if got, want := fir.FIR[0].Reserved, uint32(0xabcdef); got != want {
+		t.Fatalf("Reserved = %x, want %x", got, want)
+	}
+
+	preserved, err := MarshalOptions{PreserveReserved: true}.Marshal([]Packet{&fir})
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+	if !reflect.DeepEqual(preserved, data) {
+		t.Errorf("Marshal with PreserveReserved = %x, want %x", preserved, data)
+	}
+
+	zeroed, err := fir.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+	if got := zeroed[len(zeroed)-3:]; !reflect.DeepEqual(got, []byte{0, 0, 0}) {
+		t.Errorf("Marshal reserved bits = %x, want zero", got)
+	}
+}
@@ -3,6 +3,7 @@ package rtcp
 import (
 	"encoding/binary"
 	"fmt"
+	"strings"
 )
 
 // The Goodbye packet indicates that one or more sources are no longer active.
@@ -137,6 +138,11 @@ func (g *Goodbye) len() int {
 	return l + getPadding(l)
 }
 
+// Reset clears the Goodbye so it can be reused.
+func (g *Goodbye) Reset() {
+	*g = Goodbye{}
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (g *Goodbye) DestinationSSRC() []uint32 {
 	out := make([]uint32, len(g.Sources))
@@ -153,3 +159,32 @@ func (g Goodbye) String() string {
 
 	return out
 }
+
+// Summary returns a one-line description of the Goodbye for operational
+// logs, such as: BYE ssrcs=[0x1234,0x5678] reason="shutting down"
+func (g *Goodbye) Summary() string {
+	ssrcs := make([]string, len(g.Sources))
+	for i, s := range g.Sources {
+		ssrcs[i] = fmt.Sprintf("%#x", s)
+	}
+	return fmt.Sprintf("BYE ssrcs=[%s] reason=%q", strings.Join(ssrcs, ","), g.Reason)
+}
+
+// RelayGoodbye returns a copy of g for an SFU to re-emit on behalf of a
+// departing participant: each source is replaced by its mapping and the
+// reason is kept. g is not modified. An error is returned if a source has
+// no mapping, since relaying it would leak an upstream SSRC.
+func RelayGoodbye(g *Goodbye, mapping map[uint32]uint32) (*Goodbye, error) {
+	out := &Goodbye{
+		Sources: make([]uint32, len(g.Sources)),
+		Reason:  g.Reason,
+	}
+	for i, s := range g.Sources {
+		mapped, ok := mapping[s]
+		if !ok {
+			return nil, fmt.Errorf("%w: %#x", errUnmappedSSRC, s)
+		}
+		out.Sources[i] = mapped
+	}
+	return out, nil
+}
@@ -211,3 +211,48 @@ func TestGoodbyeRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestGoodbyeSummary(t *testing.T) {
+	for _, test := range []struct {
+		Bye  Goodbye
+		Want string
+	}{
+		{
+			Bye:  Goodbye{Sources: []uint32{0x1234, 0x5678}, Reason: "shutting down"},
+			Want: `BYE ssrcs=[0x1234,0x5678] reason="shutting down"`,
+		},
+		{
+			Bye:  Goodbye{Sources: []uint32{0x902f9e2e}},
+			Want: `BYE ssrcs=[0x902f9e2e] reason=""`,
+		},
+		{
+			Bye:  Goodbye{Reason: "say \"bye\""},
+			Want: `BYE ssrcs=[] reason="say \"bye\""`,
+		},
+	} {
+		if got := test.Bye.Summary(); got != test.Want {
+			t.Errorf("Summary() = %s, want %s", got, test.Want)
+		}
+	}
+}
+
+func TestRelayGoodbye(t *testing.T) {
+	g := &Goodbye{Sources: []uint32{0x1234, 0x5678}, Reason: "shutting down"}
+	mapping := map[uint32]uint32{0x1234: 0xaaaa, 0x5678: 0xbbbb}
+
+	relayed, err := RelayGoodbye(g, mapping)
+	if err != nil {
+		t.Fatalf("RelayGoodbye err = %v", err)
+	}
+	if got, want := relayed, (&Goodbye{Sources: []uint32{0xaaaa, 0xbbbb}, Reason: "shutting down"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("RelayGoodbye = %v, want %v", got, want)
+	}
+	if got, want := g.Sources, []uint32{0x1234, 0x5678}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RelayGoodbye modified the original sources: %x", got)
+	}
+
+	delete(mapping, 0x5678)
+	if _, err := RelayGoodbye(g, mapping); !errors.Is(err, errUnmappedSSRC) {
+		t.Errorf("RelayGoodbye with unmapped source err = %v, want %v", err, errUnmappedSSRC)
+	}
+}
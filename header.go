@@ -27,10 +27,14 @@ const (
 	FormatFIR  uint8 = 4
 	FormatTLN  uint8 = 1
 	FormatRRR  uint8 = 5
+	FormatECN  uint8 = 8
 	FormatREMB uint8 = 15
 
 	// https://tools.ietf.org/html/draft-holmer-rmcat-transport-wide-cc-extensions-01#page-5
 	FormatTCC uint8 = 15
+
+	// https://www.rfc-editor.org/rfc/rfc8888.html#section-3.1
+	FormatCCFB uint8 = 11
 )
 
 func (p PacketType) String() string {
@@ -82,8 +86,21 @@ const (
 	countShift   = 0
 	countMask    = 0x1f
 	countMax     = (1 << 5) - 1
+
+	// maxPacketLength is the size in octets of the largest packet whose
+	// length the 16-bit header length field can describe.
+	maxPacketLength = (0xFFFF + 1) * 4
 )
 
+// checkPacketLength returns errPacketTooLong if a packet of size octets
+// is too large for its length to be stored in a Header.
+func checkPacketLength(size int) error {
+	if size > maxPacketLength {
+		return errPacketTooLong
+	}
+	return nil
+}
+
 // Marshal encodes the Header in binary
 func (h Header) Marshal() ([]byte, error) {
 	/*
@@ -141,3 +158,85 @@ func (h *Header) Unmarshal(rawPacket []byte) error {
 
 	return nil
 }
+
+// ReceptionCount returns the number of reception report blocks announced
+// by the count field of an SR or RR header.
+func (h Header) ReceptionCount() (uint8, error) {
+	if h.Type != TypeSenderReport && h.Type != TypeReceiverReport {
+		return 0, errWrongType
+	}
+	return h.Count, nil
+}
+
+// SourceCount returns the number of SSRC/CSRC entries announced by the
+// count field of an SDES or BYE header.
+func (h Header) SourceCount() (uint8, error) {
+	if h.Type != TypeSourceDescription && h.Type != TypeGoodbye {
+		return 0, errWrongType
+	}
+	return h.Count, nil
+}
+
+// FMT returns the feedback message type carried in the count field of a
+// transport-layer or payload-specific feedback header.
+func (h Header) FMT() (uint8, error) {
+	if h.Type != TypeTransportSpecificFeedback && h.Type != TypePayloadSpecificFeedback {
+		return 0, errWrongType
+	}
+	return h.Count, nil
+}
+
+// trailingPadding returns the padding count that ends rawPacket, a packet
+// with the padding bit set, checking that the padding does not reach into
+// the first min octets.
+func trailingPadding(rawPacket []byte, min int) (int, error) {
+	padding := int(rawPacket[len(rawPacket)-1])
+	if padding == 0 || len(rawPacket)-padding < min {
+		return 0, errWrongPadding
+	}
+	return padding, nil
+}
+
+// reusePadding returns the number of padding octets to write after n
+// octets of content, for a packet decoded with want padding octets: want
+// itself if that keeps the packet 32-bit aligned, otherwise the fewest
+// octets, at least one, that do. It returns 0 if want is 0.
+func reusePadding(n, want int) int {
+	if want == 0 || (n+want)%4 == 0 {
+		return want
+	}
+	if p := getPadding(n); p != 0 {
+		return p
+	}
+	return 4
+}
+
+// appendPadding appends padding octets ending with their count to b.
+func appendPadding(b []byte, padding int) []byte {
+	if padding == 0 {
+		return b
+	}
+	b = append(b, make([]byte, padding)...)
+	b[len(b)-1] = uint8(padding)
+	return b
+}
+
+// contentLength returns the number of bytes at the start of rawPacket that
+// make up the packet described by h, header included and trailing padding
+// octets excluded. When the padding bit is set, the last octet of the
+// packet holds the padding count and must be consistent with its length.
+func contentLength(h Header, rawPacket []byte) (int, error) {
+	n := int(h.Length+1) * 4
+	if len(rawPacket) < n {
+		return 0, errPacketTooShort
+	}
+	if !h.Padding {
+		return n, nil
+	}
+
+	padding := int(rawPacket[n-1])
+	if padding == 0 || padding > n-headerLength {
+		return 0, errWrongPadding
+	}
+	return n - padding, nil
+}
@@ -113,3 +113,37 @@ func TestHeaderRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestHeaderCountAccessors(t *testing.T) {
+	for _, test := range []struct {
+		Type                               PacketType
+		WantReception, WantSource, WantFMT error
+	}{
+		{TypeSenderReport, nil, errWrongType, errWrongType},
+		{TypeReceiverReport, nil, errWrongType, errWrongType},
+		{TypeSourceDescription, errWrongType, nil, errWrongType},
+		{TypeGoodbye, errWrongType, nil, errWrongType},
+		{TypeTransportSpecificFeedback, errWrongType, errWrongType, nil},
+		{TypePayloadSpecificFeedback, errWrongType, errWrongType, nil},
+		{TypeExtendedReport, errWrongType, errWrongType, errWrongType},
+	} {
+		h := Header{Type: test.Type, Count: 5}
+		for _, accessor := range []struct {
+			Name    string
+			Get     func() (uint8, error)
+			WantErr error
+		}{
+			{"ReceptionCount", h.ReceptionCount, test.WantReception},
+			{"SourceCount", h.SourceCount, test.WantSource},
+			{"FMT", h.FMT, test.WantFMT},
+		} {
+			got, err := accessor.Get()
+			if !errors.Is(err, accessor.WantErr) {
+				t.Errorf("%s %s: err = %v, want %v", test.Type, accessor.Name, err, accessor.WantErr)
+			}
+			if err == nil && got != 5 {
+				t.Errorf("%s %s = %d, want 5", test.Type, accessor.Name, got)
+			}
+		}
+	}
+}
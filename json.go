@@ -0,0 +1,340 @@
+package rtcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The JSON encodings below are meant for logging and debug endpoints.
+// SSRCs are rendered as hex strings, and the 64-bit NTP timestamp of a
+// SenderReport is rendered both as a hex string, which is what is read
+// back by UnmarshalJSON, and as an informational RFC 3339 wallclock time.
+
+// jsonSSRC is an SSRC that encodes as a "0x"-prefixed hex string.
+type jsonSSRC uint32
+
+func (s jsonSSRC) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("0x%08x", uint32(s)))
+}
+
+func (s *jsonSSRC) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	v, err := strconv.ParseUint(strings.TrimPrefix(str, "0x"), 16, 32)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidJSONSSRC, err)
+	}
+	*s = jsonSSRC(v)
+	return nil
+}
+
+func jsonSSRCs(ssrcs []uint32) []jsonSSRC {
+	out := make([]jsonSSRC, len(ssrcs))
+	for i, s := range ssrcs {
+		out[i] = jsonSSRC(s)
+	}
+	return out
+}
+
+// jsonNTPTime is an NTP timestamp that encodes as a "0x"-prefixed hex string.
+type jsonNTPTime uint64
+
+func (t jsonNTPTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("0x%016x", uint64(t)))
+}
+
+func (t *jsonNTPTime) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	v, err := strconv.ParseUint(strings.TrimPrefix(str, "0x"), 16, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidJSONNTPTime, err)
+	}
+	*t = jsonNTPTime(v)
+	return nil
+}
+
+// jsonSDESType is an SDES item type that encodes as its name.
+type jsonSDESType SDESType
+
+func (t jsonSDESType) MarshalJSON() ([]byte, error) {
+	if SDESType(t) > SDESPrivate {
+		return json.Marshal(uint8(t))
+	}
+	return json.Marshal(SDESType(t).String())
+}
+
+func (t *jsonSDESType) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		var n uint8
+		if err := json.Unmarshal(b, &n); err != nil {
+			return err
+		}
+		*t = jsonSDESType(n)
+		return nil
+	}
+	for s := SDESEnd; s <= SDESPrivate; s++ {
+		if s.String() == str {
+			*t = jsonSDESType(s)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", errInvalidJSONSDESType, str)
+}
+
+func checkJSONType(got, want string) error {
+	if got != want {
+		return fmt.Errorf("%w: got %q, want %q", errWrongType, got, want)
+	}
+	return nil
+}
+
+type jsonReceptionReport struct {
+	SSRC               jsonSSRC `json:"ssrc"`
+	FractionLost       uint8    `json:"fractionLost"`
+	TotalLost          uint32   `json:"totalLost"`
+	LastSequenceNumber uint32   `json:"lastSequenceNumber"`
+	Jitter             uint32   `json:"jitter"`
+	LastSenderReport   uint32   `json:"lastSenderReport"`
+	Delay              uint32   `json:"delay"`
+}
+
+func toJSONReceptionReports(reports []ReceptionReport) []jsonReceptionReport {
+	out := make([]jsonReceptionReport, len(reports))
+	for i, r := range reports {
+		out[i] = jsonReceptionReport{
+			SSRC:               jsonSSRC(r.SSRC),
+			FractionLost:       r.FractionLost,
+			TotalLost:          r.TotalLost,
+			LastSequenceNumber: r.LastSequenceNumber,
+			Jitter:             r.Jitter,
+			LastSenderReport:   r.LastSenderReport,
+			Delay:              r.Delay,
+		}
+	}
+	return out
+}
+
+func fromJSONReceptionReports(reports []jsonReceptionReport) []ReceptionReport {
+	if len(reports) == 0 {
+		return nil
+	}
+	out := make([]ReceptionReport, len(reports))
+	for i, r := range reports {
+		out[i] = ReceptionReport{
+			SSRC:               uint32(r.SSRC),
+			FractionLost:       r.FractionLost,
+			TotalLost:          r.TotalLost,
+			LastSequenceNumber: r.LastSequenceNumber,
+			Jitter:             r.Jitter,
+			LastSenderReport:   r.LastSenderReport,
+			Delay:              r.Delay,
+		}
+	}
+	return out
+}
+
+type jsonSenderReport struct {
+	Type              string                `json:"type"`
+	SSRC              jsonSSRC              `json:"ssrc"`
+	NTPTime           jsonNTPTime           `json:"ntpTime"`
+	Wallclock         string                `json:"wallclock,omitempty"`
+	RTPTime           uint32                `json:"rtpTime"`
+	PacketCount       uint32                `json:"packetCount"`
+	OctetCount        uint32                `json:"octetCount"`
+	Reports           []jsonReceptionReport `json:"reports"`
+	ProfileExtensions []byte                `json:"profileExtensions,omitempty"`
+}
+
+// MarshalJSON encodes the SenderReport as JSON.
+func (r SenderReport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonSenderReport{
+		Type:              TypeSenderReport.String(),
+		SSRC:              jsonSSRC(r.SSRC),
+		NTPTime:           jsonNTPTime(r.NTPTime),
+		Wallclock:         ntpToTime(r.NTPTime).Format(time.RFC3339Nano),
+		RTPTime:           r.RTPTime,
+		PacketCount:       r.PacketCount,
+		OctetCount:        r.OctetCount,
+		Reports:           toJSONReceptionReports(r.Reports),
+		ProfileExtensions: r.ProfileExtensions,
+	})
+}
+
+// UnmarshalJSON decodes the SenderReport from JSON produced by MarshalJSON.
+func (r *SenderReport) UnmarshalJSON(b []byte) error {
+	var j jsonSenderReport
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	if err := checkJSONType(j.Type, TypeSenderReport.String()); err != nil {
+		return err
+	}
+
+	*r = SenderReport{
+		SSRC:              uint32(j.SSRC),
+		NTPTime:           uint64(j.NTPTime),
+		RTPTime:           j.RTPTime,
+		PacketCount:       j.PacketCount,
+		OctetCount:        j.OctetCount,
+		Reports:           fromJSONReceptionReports(j.Reports),
+		ProfileExtensions: j.ProfileExtensions,
+	}
+	return nil
+}
+
+type jsonReceiverReport struct {
+	Type              string                `json:"type"`
+	SSRC              jsonSSRC              `json:"ssrc"`
+	Reports           []jsonReceptionReport `json:"reports"`
+	ProfileExtensions []byte                `json:"profileExtensions,omitempty"`
+}
+
+// MarshalJSON encodes the ReceiverReport as JSON.
+func (r ReceiverReport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonReceiverReport{
+		Type:              TypeReceiverReport.String(),
+		SSRC:              jsonSSRC(r.SSRC),
+		Reports:           toJSONReceptionReports(r.Reports),
+		ProfileExtensions: r.ProfileExtensions,
+	})
+}
+
+// UnmarshalJSON decodes the ReceiverReport from JSON produced by MarshalJSON.
+func (r *ReceiverReport) UnmarshalJSON(b []byte) error {
+	var j jsonReceiverReport
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	if err := checkJSONType(j.Type, TypeReceiverReport.String()); err != nil {
+		return err
+	}
+
+	*r = ReceiverReport{
+		SSRC:              uint32(j.SSRC),
+		Reports:           fromJSONReceptionReports(j.Reports),
+		ProfileExtensions: j.ProfileExtensions,
+	}
+	return nil
+}
+
+type jsonSourceDescriptionItem struct {
+	Type jsonSDESType `json:"type"`
+	Text string       `json:"text"`
+}
+
+type jsonSourceDescriptionChunk struct {
+	Source jsonSSRC                    `json:"source"`
+	Items  []jsonSourceDescriptionItem `json:"items"`
+}
+
+type jsonSourceDescription struct {
+	Type   string                       `json:"type"`
+	Chunks []jsonSourceDescriptionChunk `json:"chunks"`
+}
+
+// MarshalJSON encodes the SourceDescription as JSON.
+func (s SourceDescription) MarshalJSON() ([]byte, error) {
+	j := jsonSourceDescription{
+		Type:   TypeSourceDescription.String(),
+		Chunks: make([]jsonSourceDescriptionChunk, len(s.Chunks)),
+	}
+	for i, c := range s.Chunks {
+		items := make([]jsonSourceDescriptionItem, len(c.Items))
+		for k, it := range c.Items {
+			items[k] = jsonSourceDescriptionItem{Type: jsonSDESType(it.Type), Text: it.Text}
+		}
+		j.Chunks[i] = jsonSourceDescriptionChunk{Source: jsonSSRC(c.Source), Items: items}
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes the SourceDescription from JSON produced by MarshalJSON.
+func (s *SourceDescription) UnmarshalJSON(b []byte) error {
+	var j jsonSourceDescription
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	if err := checkJSONType(j.Type, TypeSourceDescription.String()); err != nil {
+		return err
+	}
+
+	*s = SourceDescription{}
+	for _, c := range j.Chunks {
+		chunk := SourceDescriptionChunk{Source: uint32(c.Source)}
+		for _, it := range c.Items {
+			chunk.Items = append(chunk.Items, SourceDescriptionItem{Type: SDESType(it.Type), Text: it.Text})
+		}
+		s.Chunks = append(s.Chunks, chunk)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the Goodbye as JSON.
+func (g Goodbye) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type    string     `json:"type"`
+		Sources []jsonSSRC `json:"sources"`
+		Reason  string     `json:"reason,omitempty"`
+	}{
+		Type:    TypeGoodbye.String(),
+		Sources: jsonSSRCs(g.Sources),
+		Reason:  g.Reason,
+	})
+}
+
+// MarshalJSON encodes the PictureLossIndication as JSON.
+func (p PictureLossIndication) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type       string   `json:"type"`
+		SenderSSRC jsonSSRC `json:"senderSSRC"`
+		MediaSSRC  jsonSSRC `json:"mediaSSRC"`
+	}{
+		Type:       "PLI",
+		SenderSSRC: jsonSSRC(p.SenderSSRC),
+		MediaSSRC:  jsonSSRC(p.MediaSSRC),
+	})
+}
+
+// MarshalJSON encodes the TransportLayerNack as JSON. Each NackPair is
+// expanded into the list of sequence numbers it covers.
+func (p TransportLayerNack) MarshalJSON() ([]byte, error) {
+	lost := []uint16{}
+	for i := range p.Nacks {
+		lost = append(lost, p.Nacks[i].PacketList()...)
+	}
+	return json.Marshal(struct {
+		Type       string   `json:"type"`
+		SenderSSRC jsonSSRC `json:"senderSSRC"`
+		MediaSSRC  jsonSSRC `json:"mediaSSRC"`
+		Lost       []uint16 `json:"lost"`
+	}{
+		Type:       "NACK",
+		SenderSSRC: jsonSSRC(p.SenderSSRC),
+		MediaSSRC:  jsonSSRC(p.MediaSSRC),
+		Lost:       lost,
+	})
+}
+
+// MarshalJSON encodes the ReceiverEstimatedMaximumBitrate as JSON.
+func (p ReceiverEstimatedMaximumBitrate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type       string     `json:"type"`
+		SenderSSRC jsonSSRC   `json:"senderSSRC"`
+		Bitrate    float32    `json:"bitrate"`
+		SSRCs      []jsonSSRC `json:"ssrcs"`
+	}{
+		Type:       "REMB",
+		SenderSSRC: jsonSSRC(p.SenderSSRC),
+		Bitrate:    p.Bitrate,
+		SSRCs:      jsonSSRCs(p.SSRCs),
+	})
+}
@@ -0,0 +1,161 @@
+package rtcp
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSenderReportJSON(t *testing.T) {
+	sr := SenderReport{
+		SSRC:        0x902f9e2e,
+		NTPTime:     0xda8bd1fcdddda05a,
+		RTPTime:     0xaaf4edd5,
+		PacketCount: 1,
+		OctetCount:  2,
+		Reports: []ReceptionReport{{
+			SSRC:               0xbc5e9a40,
+			FractionLost:       3,
+			TotalLost:          4,
+			LastSequenceNumber: 0x46e1,
+			Jitter:             273,
+			LastSenderReport:   0x9f36432,
+			Delay:              150137,
+		}},
+	}
+
+	data, err := json.Marshal(sr)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "SR",
+		"ssrc": "0x902f9e2e",
+		"ntpTime": "0xda8bd1fcdddda05a",
+		"wallclock": "2016-03-10T10:59:08.866663Z",
+		"rtpTime": 2868178389,
+		"packetCount": 1,
+		"octetCount": 2,
+		"reports": [{
+			"ssrc": "0xbc5e9a40",
+			"fractionLost": 3,
+			"totalLost": 4,
+			"lastSequenceNumber": 18145,
+			"jitter": 273,
+			"lastSenderReport": 166945842,
+			"delay": 150137
+		}]
+	}`, string(data))
+
+	var decoded SenderReport
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, sr, decoded)
+}
+
+func TestReceiverReportJSON(t *testing.T) {
+	rr := ReceiverReport{
+		SSRC: 0x902f9e2e,
+		Reports: []ReceptionReport{{
+			SSRC:               0xbc5e9a40,
+			LastSequenceNumber: 0x46e1,
+			Jitter:             273,
+		}},
+		ProfileExtensions: []byte{0x01, 0x02, 0x03, 0x04},
+	}
+
+	data, err := json.Marshal(rr)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "RR",
+		"ssrc": "0x902f9e2e",
+		"reports": [{
+			"ssrc": "0xbc5e9a40",
+			"fractionLost": 0,
+			"totalLost": 0,
+			"lastSequenceNumber": 18145,
+			"jitter": 273,
+			"lastSenderReport": 0,
+			"delay": 0
+		}],
+		"profileExtensions": "AQIDBA=="
+	}`, string(data))
+
+	var decoded ReceiverReport
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, rr, decoded)
+
+	var sr SenderReport
+	assert.True(t, errors.Is(json.Unmarshal(data, &sr), errWrongType))
+}
+
+func TestSourceDescriptionJSON(t *testing.T) {
+	sdes := SourceDescription{
+		Chunks: []SourceDescriptionChunk{{
+			Source: 0x10000000,
+			Items: []SourceDescriptionItem{
+				{Type: SDESCNAME, Text: "cname"},
+				{Type: SDESTool, Text: "pion"},
+			},
+		}},
+	}
+
+	data, err := json.Marshal(sdes)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "SDES",
+		"chunks": [{
+			"source": "0x10000000",
+			"items": [
+				{"type": "CNAME", "text": "cname"},
+				{"type": "TOOL", "text": "pion"}
+			]
+		}]
+	}`, string(data))
+
+	var decoded SourceDescription
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, sdes, decoded)
+
+	err = json.Unmarshal([]byte(`{"type":"SDES","chunks":[{"source":"0x1","items":[{"type":"BOGUS"}]}]}`), &decoded)
+	assert.True(t, errors.Is(err, errInvalidJSONSDESType))
+
+	err = json.Unmarshal([]byte(`{"type":"SDES","chunks":[{"source":"nope"}]}`), &decoded)
+	assert.True(t, errors.Is(err, errInvalidJSONSSRC))
+}
+
+func TestFeedbackJSON(t *testing.T) {
+	for _, test := range []struct {
+		Name   string
+		Packet Packet
+		Want   string
+	}{
+		{
+			Name:   "goodbye",
+			Packet: &Goodbye{Sources: []uint32{0x1234, 0x5678}, Reason: "bye"},
+			Want:   `{"type":"BYE","sources":["0x00001234","0x00005678"],"reason":"bye"}`,
+		},
+		{
+			Name:   "pli",
+			Packet: &PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0x2},
+			Want:   `{"type":"PLI","senderSSRC":"0x00000001","mediaSSRC":"0x00000002"}`,
+		},
+		{
+			Name: "nack",
+			Packet: &TransportLayerNack{
+				SenderSSRC: 0x1,
+				MediaSSRC:  0x2,
+				Nacks:      []NackPair{{PacketID: 100, LostPackets: 0x0005}},
+			},
+			Want: `{"type":"NACK","senderSSRC":"0x00000001","mediaSSRC":"0x00000002","lost":[100,101,103]}`,
+		},
+		{
+			Name:   "remb",
+			Packet: &ReceiverEstimatedMaximumBitrate{SenderSSRC: 0x1, Bitrate: 8927168, SSRCs: []uint32{0x3}},
+			Want:   `{"type":"REMB","senderSSRC":"0x00000001","bitrate":8927168,"ssrcs":["0x00000003"]}`,
+		},
+	} {
+		data, err := json.Marshal(test.Packet)
+		assert.NoError(t, err, test.Name)
+		assert.JSONEq(t, test.Want, string(data), test.Name)
+	}
+}
@@ -0,0 +1,59 @@
+package rtcp
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// LivenessTracker records when RTCP was last received from each SSRC, so
+// that receivers can time out sources that have gone silent, as described
+// in RFC 3550 section 6.3.5. Like BandwidthMeter it never reads a clock;
+// callers pass in the time of every event.
+//
+// The zero value is ready to use. A LivenessTracker is safe for concurrent
+// use.
+type LivenessTracker struct {
+	mu       sync.Mutex
+	lastSeen map[uint32]time.Time
+}
+
+// Seen records that RTCP from ssrc was received at time at. Earlier times
+// than the one already recorded for ssrc are ignored.
+func (l *LivenessTracker) Seen(ssrc uint32, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lastSeen == nil {
+		l.lastSeen = map[uint32]time.Time{}
+	}
+	if last, ok := l.lastSeen[ssrc]; !ok || at.After(last) {
+		l.lastSeen[ssrc] = at
+	}
+}
+
+// TimedOut returns, in ascending order, the SSRCs that have not been seen
+// for longer than timeout as of now. They are forgotten, so each source is
+// reported once per silence; a source seen again is tracked anew.
+func (l *LivenessTracker) TimedOut(now time.Time, timeout time.Duration) []uint32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []uint32
+	for ssrc, last := range l.lastSeen {
+		if now.Sub(last) > timeout {
+			out = append(out, ssrc)
+			delete(l.lastSeen, ssrc)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// Reset forgets every tracked SSRC.
+func (l *LivenessTracker) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastSeen = nil
+}
@@ -0,0 +1,50 @@
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLivenessTracker(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeout := 25 * time.Second
+
+	var l LivenessTracker
+	assert.Empty(t, l.TimedOut(start, timeout))
+
+	l.Seen(0x1111, start)
+	l.Seen(0x2222, start)
+	for i := 1; i <= 6; i++ {
+		l.Seen(0x1111, start.Add(time.Duration(i)*5*time.Second))
+	}
+	// Out of order arrivals do not move the deadline back.
+	l.Seen(0x1111, start)
+
+	now := start.Add(timeout)
+	assert.Empty(t, l.TimedOut(now, timeout))
+
+	now = now.Add(time.Second)
+	assert.Equal(t, []uint32{0x2222}, l.TimedOut(now, timeout))
+	assert.Empty(t, l.TimedOut(now, timeout))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, []uint32{0x1111}, l.TimedOut(now, timeout))
+
+	l.Seen(0x2222, now)
+	assert.Empty(t, l.TimedOut(now.Add(timeout), timeout))
+}
+
+func TestLivenessTrackerReset(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeout := 25 * time.Second
+
+	var l LivenessTracker
+	l.Seen(0x1111, start)
+	l.Reset()
+	assert.Empty(t, l.TimedOut(start.Add(time.Minute), timeout))
+
+	l.Seen(0x2222, start)
+	assert.Equal(t, []uint32{0x2222}, l.TimedOut(start.Add(time.Minute), timeout))
+}
@@ -0,0 +1,31 @@
+package rtcp
+
+import "sync/atomic"
+
+// Logger receives the diagnostic messages of this package, such as the
+// reason a packet failed to decode. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// loggerHolder wraps the Logger so that atomic.Value, which cannot store
+// nil, can hold an unset Logger.
+type loggerHolder struct {
+	l Logger
+}
+
+//nolint:gochecknoglobals
+var logger atomic.Value
+
+// SetLogger routes the diagnostic messages of this package to l. By
+// default, and after SetLogger(nil), nothing is logged; errors are only
+// reported through return values.
+func SetLogger(l Logger) {
+	logger.Store(loggerHolder{l: l})
+}
+
+func logf(format string, v ...interface{}) {
+	if h, _ := logger.Load().(loggerHolder); h.l != nil {
+		h.l.Printf(format, v...)
+	}
+}
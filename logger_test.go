@@ -0,0 +1,54 @@
+package rtcp
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(format, v...))
+}
+
+func TestSetLogger(t *testing.T) {
+	// An SR whose header claims a reception report that is not there.
+	bad := []byte{
+		0x81, 0xc8, 0x0, 0x6,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0xda, 0x8b, 0xd1, 0xfc,
+		0xdd, 0xdd, 0xa0, 0x5a,
+		0xaa, 0xf4, 0xed, 0xd5,
+		0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x02,
+	}
+
+	var std bytes.Buffer
+	log.SetOutput(&std)
+	defer log.SetOutput(os.Stderr)
+
+	_, _, _, err := Unmarshal(bad)
+	assert.Error(t, err)
+	assert.Empty(t, std.String())
+
+	l := &recordingLogger{}
+	SetLogger(l)
+	defer SetLogger(nil)
+
+	_, _, _, err = Unmarshal(bad)
+	assert.Error(t, err)
+	assert.Equal(t, []string{fmt.Sprintf("rtcp: failed to decode SR packet: %v", err)}, l.messages)
+	assert.Empty(t, std.String())
+
+	l.messages = nil
+	_, _, _, err = Unmarshal(realPacket())
+	assert.NoError(t, err)
+	assert.Empty(t, l.messages)
+}
@@ -0,0 +1,189 @@
+package rtcp
+
+import "time"
+
+// Report is implemented by the packets that carry reception report blocks,
+// namely SenderReport and ReceiverReport.
+type Report interface {
+	Packet
+
+	// ReceptionReports returns the reception report blocks carried by the packet.
+	ReceptionReports() []ReceptionReport
+}
+
+var (
+	_ Report = (*SenderReport)(nil)
+	_ Report = (*ReceiverReport)(nil)
+)
+
+// ReportMetrics is a library-agnostic representation of a single reception
+// report block, suitable for handing to a metrics exporter.
+type ReportMetrics struct {
+	// The SSRC of the source the statistics pertain to.
+	SSRC uint32
+	// The fraction of packets lost since the previous report, between 0 and 1.
+	FractionLost float64
+	// The total number of packets lost since the beginning of reception.
+	CumulativeLost uint32
+	// The interarrival jitter, in RTP timestamp units.
+	Jitter uint32
+	// The round-trip time to the source, or zero if unknown.
+	RTT time.Duration
+}
+
+// MetricsFromReport converts the reception report blocks of r into
+// ReportMetrics. If rttLookup is not nil it is called with the SSRC of each
+// block to fill in the RTT field.
+func MetricsFromReport(r Report, rttLookup func(uint32) time.Duration) []ReportMetrics {
+	reports := r.ReceptionReports()
+	out := make([]ReportMetrics, len(reports))
+	for i, rr := range reports {
+		out[i] = ReportMetrics{
+			SSRC:           rr.SSRC,
+			FractionLost:   float64(rr.FractionLost) / 256,
+			CumulativeLost: rr.TotalLost,
+			Jitter:         rr.Jitter,
+		}
+		if rttLookup != nil {
+			out[i].RTT = rttLookup(rr.SSRC)
+		}
+	}
+	return out
+}
+
+// SendBitrate returns the rate, in bits per second, at which the sender of
+// two consecutive SenderReports sent RTP payload between them. Both the
+// octet count and the NTP timestamp may have wrapped around in between.
+// An error is returned if cur is not later than prev.
+func SendBitrate(prev, cur *SenderReport) (float64, error) {
+	elapsed := int64(cur.NTPTime - prev.NTPTime)
+	if elapsed <= 0 {
+		return 0, errNonIncreasingNTPTime
+	}
+
+	octets := cur.OctetCount - prev.OctetCount
+	seconds := float64(elapsed) / (1 << 32)
+	return float64(octets) * 8 / seconds, nil
+}
+
+// ClockDrift estimates how fast the RTP clock of the sender of two
+// SenderReports runs relative to its NTP clock, in parts per million: the
+// difference between the RTP and NTP time advance, divided by the NTP time
+// advance. A positive result means the RTP clock runs fast. clockRate is
+// the RTP clock rate of the media in Hz. The RTP timestamp is assumed to
+// advance between reports and may have wrapped around, as may the NTP
+// timestamp. An error is returned if cur is not later than prev or if
+// clockRate is 0.
+func ClockDrift(prev, cur *SenderReport, clockRate uint32) (ppm float64, err error) {
+	if clockRate == 0 {
+		return 0, errInvalidClockRate
+	}
+
+	elapsed := int64(cur.NTPTime - prev.NTPTime)
+	if elapsed <= 0 {
+		return 0, errNonIncreasingNTPTime
+	}
+
+	ntpSeconds := float64(elapsed) / (1 << 32)
+	rtpSeconds := float64(cur.RTPTime-prev.RTPTime) / float64(clockRate)
+	return (rtpSeconds - ntpSeconds) / ntpSeconds * 1e6, nil
+}
+
+// LossEvents compares two consecutive reception reports about the same
+// source and returns how many packets were lost and received in between,
+// the input loss-based congestion controllers need. The cumulative loss
+// count is a 24-bit field that may wrap, and may go down when duplicates
+// arrive, in which case newLost is 0 and the duplicates count as received.
+//
+// An error is returned if the reports are about different sources, if the
+// extended highest sequence number went backwards, or if more packets were
+// lost than expected.
+func LossEvents(prev, cur *ReceptionReport) (newLost uint32, newReceived uint32, err error) {
+	if prev.SSRC != cur.SSRC {
+		return 0, 0, errReportSSRCMismatch
+	}
+
+	expected := int64(int32(cur.LastSequenceNumber - prev.LastSequenceNumber))
+	if expected < 0 {
+		return 0, 0, errSequenceNumberRegressed
+	}
+
+	// Sign-extend the 24-bit difference of the cumulative loss counts
+	lost := int64(int32((cur.TotalLost-prev.TotalLost)<<8) >> 8)
+	if lost > expected {
+		return 0, 0, errLossExceedsExpected
+	}
+
+	received := expected - lost
+	if lost < 0 {
+		lost = 0
+	}
+	return uint32(lost), uint32(received), nil
+}
+
+// LossPercentBetween returns the percentage, from 0 to 100, of the packets
+// in the interval between two consecutive reception reports that were
+// lost, as computed by LossEvents, for display. If no packets were
+// expected in the interval the result is 0. The errors are those of
+// LossEvents.
+func LossPercentBetween(prev, cur *ReceptionReport) (float64, error) {
+	lost, received, err := LossEvents(prev, cur)
+	if err != nil {
+		return 0, err
+	}
+
+	total := float64(lost) + float64(received)
+	if total == 0 {
+		return 0, nil
+	}
+	return float64(lost) / total * 100, nil
+}
+
+// RoundTripTime returns the round-trip time to the source of a reception
+// report block that arrived at arrival, computed as in RFC 3550 section
+// 6.4.1 from the last SR timestamp and the delay since it. The result has
+// a resolution of 1/65536 seconds. An error is returned if the source has
+// not received an SR yet, or if the report claims a delay that would make
+// the round-trip time negative.
+func RoundTripTime(r *ReceptionReport, arrival time.Time) (time.Duration, error) {
+	if r.LastSenderReport == 0 {
+		return 0, errRTTUnavailable
+	}
+
+	// Middle 32 bits of the NTP timestamp, in units of 1/65536 seconds.
+	now := uint32(timeToNTP(arrival) >> 16)
+	rtt := int32(now - r.LastSenderReport - r.Delay)
+	if rtt < 0 {
+		return 0, errRTTUnavailable
+	}
+	return time.Duration(int64(rtt) * int64(time.Second) >> 16), nil
+}
+
+// EstimateMOS returns an estimated mean opinion score, from 1 (bad) to 4.5
+// (best achievable by narrowband voice), for a stream with the given
+// fraction of packets lost (between 0 and 1), interarrival jitter and
+// round-trip time. The score is only an approximation: it uses a common
+// simplification of the ITU-T G.107 E-model that assumes a G.711 codec, a
+// jitter buffer of twice the jitter and 10 ms of fixed delay, and it
+// ignores burstiness. Use the VoIP metrics XR block when the receiver
+// sends one.
+func EstimateMOS(lossFraction float64, jitter time.Duration, rtt time.Duration) float64 {
+	// One-way mouth-to-ear delay, in milliseconds.
+	delay := float64(rtt/2+2*jitter)/float64(time.Millisecond) + 10
+
+	r := 93.2
+	if delay < 160 {
+		r -= delay / 40
+	} else {
+		r -= (delay - 120) / 10
+	}
+	r -= 2.5 * lossFraction * 100
+
+	switch {
+	case r <= 0:
+		return 1
+	case r >= 100:
+		return 4.5
+	}
+	return 1 + 0.035*r + 7e-6*r*(r-60)*(100-r)
+}
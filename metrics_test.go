@@ -0,0 +1,323 @@
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsFromReport(t *testing.T) {
+	data := []byte{
+		// v=2, p=0, count=2, RR, len=13
+		0x82, 0xc9, 0x0, 0xd,
+		// ssrc=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// ssrc=0xbc5e9a40
+		0xbc, 0x5e, 0x9a, 0x40,
+		// fracLost=64, totalLost=3
+		0x40, 0x0, 0x0, 0x3,
+		// lastSeq=0x46e1
+		0x0, 0x0, 0x46, 0xe1,
+		// jitter=273
+		0x0, 0x0, 0x1, 0x11,
+		// lsr=0x9f36432
+		0x9, 0xf3, 0x64, 0x32,
+		// delay=150137
+		0x0, 0x2, 0x4a, 0x79,
+		// ssrc=0x12345678
+		0x12, 0x34, 0x56, 0x78,
+		// fracLost=0, totalLost=0
+		0x0, 0x0, 0x0, 0x0,
+		// lastSeq=0x1
+		0x0, 0x0, 0x0, 0x1,
+		// jitter=5
+		0x0, 0x0, 0x0, 0x5,
+		// lsr=0
+		0x0, 0x0, 0x0, 0x0,
+		// delay=0
+		0x0, 0x0, 0x0, 0x0,
+	}
+
+	var rr ReceiverReport
+	assert.NoError(t, rr.Unmarshal(data))
+
+	rtts := map[uint32]time.Duration{0xbc5e9a40: 40 * time.Millisecond}
+	got := MetricsFromReport(&rr, func(ssrc uint32) time.Duration {
+		return rtts[ssrc]
+	})
+
+	assert.Equal(t, []ReportMetrics{
+		{SSRC: 0xbc5e9a40, FractionLost: 0.25, CumulativeLost: 3, Jitter: 273, RTT: 40 * time.Millisecond},
+		{SSRC: 0x12345678, FractionLost: 0, CumulativeLost: 0, Jitter: 5},
+	}, got)
+
+	assert.Equal(t, []ReportMetrics{
+		{SSRC: 0xbc5e9a40, FractionLost: 0.25, CumulativeLost: 3, Jitter: 273},
+		{SSRC: 0x12345678, FractionLost: 0, CumulativeLost: 0, Jitter: 5},
+	}, MetricsFromReport(&rr, nil))
+}
+
+func TestSendBitrate(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Prev, Cur SenderReport
+		Want      float64
+		WantError error
+	}{
+		{
+			Name: "half a second",
+			Prev: SenderReport{NTPTime: 0xda8bd1fc00000000, OctetCount: 1000},
+			Cur:  SenderReport{NTPTime: 0xda8bd1fc80000000, OctetCount: 63500},
+			Want: 1000000,
+		},
+		{
+			Name: "octet count wraps",
+			Prev: SenderReport{NTPTime: 0xda8bd1fc00000000, OctetCount: 0xFFFFFF00},
+			Cur:  SenderReport{NTPTime: 0xda8bd1fe00000000, OctetCount: 0x00000100},
+			Want: 2048,
+		},
+		{
+			Name: "NTP era wraps",
+			Prev: SenderReport{NTPTime: 0xFFFFFFFF00000000, OctetCount: 0},
+			Cur:  SenderReport{NTPTime: 0x0000000100000000, OctetCount: 250},
+			Want: 1000,
+		},
+		{
+			Name:      "equal timestamps",
+			Prev:      SenderReport{NTPTime: 0xda8bd1fc00000000},
+			Cur:       SenderReport{NTPTime: 0xda8bd1fc00000000, OctetCount: 10},
+			WantError: errNonIncreasingNTPTime,
+		},
+		{
+			Name:      "regressed timestamps",
+			Prev:      SenderReport{NTPTime: 0xda8bd1fd00000000},
+			Cur:       SenderReport{NTPTime: 0xda8bd1fc00000000, OctetCount: 10},
+			WantError: errNonIncreasingNTPTime,
+		},
+	} {
+		got, err := SendBitrate(&test.Prev, &test.Cur)
+		assert.ErrorIs(t, err, test.WantError, test.Name)
+		assert.Equal(t, test.Want, got, test.Name)
+	}
+}
+
+func TestClockDrift(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Prev, Cur SenderReport
+		ClockRate uint32
+		Want      float64
+		WantError error
+	}{
+		{
+			Name:      "in sync",
+			Prev:      SenderReport{NTPTime: 0xda8bd1fc00000000, RTPTime: 1000},
+			Cur:       SenderReport{NTPTime: 0xda8bd20600000000, RTPTime: 1000 + 900000},
+			ClockRate: 90000,
+			Want:      0,
+		},
+		{
+			Name:      "RTP clock 10 ppm fast across an RTP wrap",
+			Prev:      SenderReport{NTPTime: 0xda8bd1fc00000000, RTPTime: 0xFFFF0000},
+			Cur:       SenderReport{NTPTime: 0xda8bd20600000000, RTPTime: 0xFFFF0000 + 900009 - 1<<32},
+			ClockRate: 90000,
+			Want:      10,
+		},
+		{
+			Name:      "RTP clock 20 ppm slow",
+			Prev:      SenderReport{NTPTime: 0xda8bd1fc00000000, RTPTime: 0},
+			Cur:       SenderReport{NTPTime: 0xda8bd26000000000, RTPTime: 4800000 - 96},
+			ClockRate: 48000,
+			Want:      -20,
+		},
+		{
+			Name:      "equal timestamps",
+			Prev:      SenderReport{NTPTime: 0xda8bd1fc00000000},
+			Cur:       SenderReport{NTPTime: 0xda8bd1fc00000000, RTPTime: 10},
+			ClockRate: 90000,
+			WantError: errNonIncreasingNTPTime,
+		},
+		{
+			Name:      "zero clock rate",
+			Prev:      SenderReport{NTPTime: 0xda8bd1fc00000000},
+			Cur:       SenderReport{NTPTime: 0xda8bd1fd00000000},
+			WantError: errInvalidClockRate,
+		},
+	} {
+		got, err := ClockDrift(&test.Prev, &test.Cur, test.ClockRate)
+		assert.ErrorIs(t, err, test.WantError, test.Name)
+		assert.InDelta(t, test.Want, got, 1e-6, test.Name)
+	}
+}
+
+func TestLossEvents(t *testing.T) {
+	for _, test := range []struct {
+		Name         string
+		Prev, Cur    ReceptionReport
+		WantLost     uint32
+		WantReceived uint32
+		WantError    error
+	}{
+		{
+			Name:         "steady loss",
+			Prev:         ReceptionReport{SSRC: 1, TotalLost: 10, LastSequenceNumber: 1000},
+			Cur:          ReceptionReport{SSRC: 1, TotalLost: 15, LastSequenceNumber: 1100},
+			WantLost:     5,
+			WantReceived: 95,
+		},
+		{
+			Name:         "sequence cycle",
+			Prev:         ReceptionReport{SSRC: 1, TotalLost: 0, LastSequenceNumber: 0x0000fff0},
+			Cur:          ReceptionReport{SSRC: 1, TotalLost: 2, LastSequenceNumber: 0x00010010},
+			WantLost:     2,
+			WantReceived: 30,
+		},
+		{
+			Name:         "cumulative loss wraps",
+			Prev:         ReceptionReport{SSRC: 1, TotalLost: 0xfffffe, LastSequenceNumber: 500},
+			Cur:          ReceptionReport{SSRC: 1, TotalLost: 0x000001, LastSequenceNumber: 600},
+			WantLost:     3,
+			WantReceived: 97,
+		},
+		{
+			Name:         "duplicates",
+			Prev:         ReceptionReport{SSRC: 1, TotalLost: 10, LastSequenceNumber: 500},
+			Cur:          ReceptionReport{SSRC: 1, TotalLost: 8, LastSequenceNumber: 600},
+			WantLost:     0,
+			WantReceived: 102,
+		},
+		{
+			Name:      "different sources",
+			Prev:      ReceptionReport{SSRC: 1},
+			Cur:       ReceptionReport{SSRC: 2},
+			WantError: errReportSSRCMismatch,
+		},
+		{
+			Name:      "sequence regressed",
+			Prev:      ReceptionReport{SSRC: 1, LastSequenceNumber: 600},
+			Cur:       ReceptionReport{SSRC: 1, LastSequenceNumber: 500},
+			WantError: errSequenceNumberRegressed,
+		},
+		{
+			Name:      "loss exceeds expected",
+			Prev:      ReceptionReport{SSRC: 1, TotalLost: 0, LastSequenceNumber: 500},
+			Cur:       ReceptionReport{SSRC: 1, TotalLost: 20, LastSequenceNumber: 510},
+			WantError: errLossExceedsExpected,
+		},
+	} {
+		lost, received, err := LossEvents(&test.Prev, &test.Cur)
+		assert.ErrorIs(t, err, test.WantError, test.Name)
+		assert.Equal(t, test.WantLost, lost, test.Name)
+		assert.Equal(t, test.WantReceived, received, test.Name)
+	}
+}
+
+func TestLossPercentBetween(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Prev, Cur ReceptionReport
+		Want      float64
+		WantError error
+	}{
+		{
+			Name: "5 of 200 lost",
+			Prev: ReceptionReport{SSRC: 1, TotalLost: 10, LastSequenceNumber: 1000},
+			Cur:  ReceptionReport{SSRC: 1, TotalLost: 15, LastSequenceNumber: 1200},
+			Want: 2.5,
+		},
+		{
+			Name: "nothing expected",
+			Prev: ReceptionReport{SSRC: 1, TotalLost: 10, LastSequenceNumber: 1000},
+			Cur:  ReceptionReport{SSRC: 1, TotalLost: 10, LastSequenceNumber: 1000},
+			Want: 0,
+		},
+		{
+			Name:      "different sources",
+			Prev:      ReceptionReport{SSRC: 1, LastSequenceNumber: 1000},
+			Cur:       ReceptionReport{SSRC: 2, LastSequenceNumber: 1200},
+			WantError: errReportSSRCMismatch,
+		},
+	} {
+		got, err := LossPercentBetween(&test.Prev, &test.Cur)
+		assert.ErrorIs(t, err, test.WantError, test.Name)
+		assert.Equal(t, test.Want, got, test.Name)
+	}
+}
+
+func TestEstimateMOS(t *testing.T) {
+	for _, test := range []struct {
+		Name         string
+		LossFraction float64
+		Jitter       time.Duration
+		RTT          time.Duration
+		Want         float64
+	}{
+		{"perfect network", 0, 0, 0, 4.4044},
+		{"typical network", 0.05, 20 * time.Millisecond, 200 * time.Millisecond, 3.9037},
+		{"long delay", 0.1, 0, 980 * time.Millisecond, 1.6173},
+		{"total loss", 1, 0, 0, 1},
+	} {
+		assert.InDelta(t, test.Want, EstimateMOS(test.LossFraction, test.Jitter, test.RTT), 0.0001, test.Name)
+	}
+}
+
+func TestRoundTripTimeFromArrival(t *testing.T) {
+	sent := time.Date(2026, 3, 10, 10, 59, 8, 0, time.UTC)
+	arrival := sent.Add(750 * time.Millisecond)
+
+	data, err := (&ReceiverReport{
+		SSRC: 0x902f9e2e,
+		Reports: []ReceptionReport{{
+			SSRC:             0xbc5e9a40,
+			LastSenderReport: uint32(timeToNTP(sent) >> 16),
+			Delay:            1 << 15, // 0.5s
+		}},
+	}).Marshal()
+	assert.NoError(t, err)
+
+	packets, err := UnmarshalAt(data, arrival)
+	assert.NoError(t, err)
+	rr, ok := packets[0].(*ReceiverReport)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, arrival, rr.Arrival)
+
+	rtt, err := RoundTripTime(&rr.Reports[0], rr.Arrival)
+	assert.NoError(t, err)
+	assert.InDelta(t, float64(250*time.Millisecond), float64(rtt), float64(time.Second>>16))
+
+	_, err = RoundTripTime(&ReceptionReport{}, rr.Arrival)
+	assert.ErrorIs(t, err, errRTTUnavailable)
+
+	_, err = RoundTripTime(&rr.Reports[0], sent)
+	assert.ErrorIs(t, err, errRTTUnavailable)
+}
+
+func TestRoundTripTimeBeforeSenderReport(t *testing.T) {
+	arrival := time.Date(2026, 3, 10, 10, 59, 8, 0, time.UTC)
+
+	// A receiver reporting on a source whose SR it has not received yet
+	// sends LSR = 0; the delay field is meaningless then, and must not be
+	// used to derive a round-trip time even when it is set.
+	data, err := (&ReceiverReport{
+		SSRC: 0x902f9e2e,
+		Reports: []ReceptionReport{{
+			SSRC:               0xbc5e9a40,
+			FractionLost:       10,
+			TotalLost:          3,
+			LastSequenceNumber: 0x46e1,
+			Jitter:             273,
+			Delay:              1 << 15,
+		}},
+	}).Marshal()
+	assert.NoError(t, err)
+
+	var rr ReceiverReport
+	assert.NoError(t, rr.Unmarshal(data))
+
+	rtt, err := RoundTripTime(&rr.Reports[0], arrival)
+	assert.ErrorIs(t, err, errRTTUnavailable)
+	assert.Equal(t, time.Duration(0), rtt)
+}
@@ -0,0 +1,93 @@
+package rtcp
+
+import "sync"
+
+// NackLoopDetector spots receivers that keep requesting the same packets,
+// which floods the sender with retransmissions without ever repairing the
+// loss. Like DuplicateFilter it never reads a clock: the window is the
+// most recent NACK packets observed rather than a span of time.
+//
+// The zero value has an empty window and never flags a loop. A
+// NackLoopDetector is safe for concurrent use.
+type NackLoopDetector struct {
+	mu          sync.Mutex
+	maxRequests int
+	window      [][]nackKey
+	next        int
+	counts      map[nackKey]int
+}
+
+type nackKey struct {
+	mediaSSRC uint32
+	seq       uint16
+}
+
+// NewNackLoopDetector returns a NackLoopDetector that flags a sequence
+// number requested more than maxRequests times within the last window
+// NACK packets. A window of 0 or less never flags a loop.
+func NewNackLoopDetector(maxRequests, window int) *NackLoopDetector {
+	if window < 0 {
+		window = 0
+	}
+	return &NackLoopDetector{
+		maxRequests: maxRequests,
+		window:      make([][]nackKey, 0, window),
+		counts:      make(map[nackKey]int),
+	}
+}
+
+// Observe adds nack to the window, evicting the oldest NACK if the window
+// is full, and reports whether any sequence number it requests has now
+// been requested more than maxRequests times within the window. A
+// sequence number listed twice in the same NACK counts once.
+func (d *NackLoopDetector) Observe(nack *TransportLayerNack) bool {
+	var keys []nackKey
+	seen := make(map[uint16]struct{})
+	for i := range nack.Nacks {
+		nack.Nacks[i].Range(func(seq uint16) bool {
+			if _, ok := seen[seq]; !ok {
+				seen[seq] = struct{}{}
+				keys = append(keys, nackKey{mediaSSRC: nack.MediaSSRC, seq: seq})
+			}
+			return true
+		})
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if cap(d.window) == 0 {
+		return false
+	}
+	if len(d.window) < cap(d.window) {
+		d.window = append(d.window, keys)
+	} else {
+		for _, old := range d.window[d.next] {
+			if d.counts[old]--; d.counts[old] == 0 {
+				delete(d.counts, old)
+			}
+		}
+		d.window[d.next] = keys
+		d.next = (d.next + 1) % len(d.window)
+	}
+
+	looping := false
+	for _, k := range keys {
+		d.counts[k]++
+		if d.counts[k] > d.maxRequests {
+			looping = true
+		}
+	}
+	return looping
+}
+
+// Reset forgets every NACK in the window. The limit and window size are
+// kept.
+func (d *NackLoopDetector) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.window = d.window[:0]
+	d.next = 0
+	d.counts = make(map[nackKey]int)
+}
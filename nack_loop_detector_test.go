@@ -0,0 +1,67 @@
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNackLoopDetector(t *testing.T) {
+	d := NewNackLoopDetector(2, 4)
+
+	lost := &TransportLayerNack{
+		SenderSSRC: 1,
+		MediaSSRC:  2,
+		Nacks:      NackPairsFromSequenceNumbers([]uint16{100, 101}),
+	}
+	other := &TransportLayerNack{
+		SenderSSRC: 1,
+		MediaSSRC:  2,
+		Nacks:      NackPairsFromSequenceNumbers([]uint16{200}),
+	}
+	otherSSRC := &TransportLayerNack{
+		SenderSSRC: 1,
+		MediaSSRC:  3,
+		Nacks:      NackPairsFromSequenceNumbers([]uint16{100, 101}),
+	}
+
+	assert.False(t, d.Observe(lost))
+	assert.False(t, d.Observe(lost))
+	// The same sequence numbers on another media SSRC are a different stream.
+	assert.False(t, d.Observe(otherSSRC))
+	assert.True(t, d.Observe(lost), "third request within the window")
+
+	// Once the earlier requests have left the window the count drops again.
+	assert.False(t, d.Observe(other))
+	assert.False(t, d.Observe(other))
+	assert.False(t, d.Observe(otherSSRC))
+	assert.False(t, d.Observe(lost))
+
+	d.Reset()
+	assert.False(t, d.Observe(lost))
+	assert.False(t, d.Observe(lost))
+	assert.True(t, d.Observe(lost))
+}
+
+func TestNackLoopDetectorDuplicateInPacket(t *testing.T) {
+	d := NewNackLoopDetector(1, 4)
+
+	// 100 appears both as a PacketID and in an overlapping bitmask.
+	nack := &TransportLayerNack{
+		MediaSSRC: 2,
+		Nacks:     []NackPair{{PacketID: 99, LostPackets: 0x0001}, {PacketID: 100}},
+	}
+	assert.False(t, d.Observe(nack))
+	assert.True(t, d.Observe(nack))
+}
+
+func TestNackLoopDetectorEmptyWindow(t *testing.T) {
+	lost := &TransportLayerNack{MediaSSRC: 2, Nacks: []NackPair{{PacketID: 100}}}
+
+	for _, d := range []*NackLoopDetector{NewNackLoopDetector(0, 0), NewNackLoopDetector(0, -1), {}} {
+		assert.False(t, d.Observe(lost))
+		assert.False(t, d.Observe(lost))
+		d.Reset()
+		assert.False(t, d.Observe(lost))
+	}
+}
@@ -1,7 +1,9 @@
 package rtcp
 
 import (
-	"log"
+	"fmt"
+	"strings"
+	"time"
 )
 
 // Packet represents an RTCP packet, a protocol used for out-of-band statistics and control information for an RTP session
@@ -19,18 +21,31 @@ type Packet interface {
 // If this is a reduced-size RTCP packet a feedback packet (Goodbye, SliceLossIndication, etc)
 // will be returned. Otherwise, the underlying type of the returned packet will be
 // CompoundPacket.
+//
+// The NTP timestamp and packet count of the first SenderReport in the datagram are
+// also returned, or zero if there is none. A datagram from a mixer may carry one
+// SenderReport per mixed source; each is returned, and the timing of every one of
+// them can be read from its NTPTime and PacketCount fields.
+//
+// Feedback packets whose FMT is not valid for their packet type, such as a
+// NACK sent as payload-specific feedback, are returned undecoded as a
+// RawPacket, and a warning is sent to the Logger set with SetLogger.
 func Unmarshal(rawData []byte) ([]Packet, uint64, uint32, error) {
 	var packets []Packet
+	var ntpTimestamp uint64
+	var packetCount uint32
+	seenSenderReport := false
 	for len(rawData) != 0 {
-		p, processed, ntpTimestamp, packetCount, isSenderReport, err := unmarshal(rawData)
-
-		if isSenderReport && ntpTimestamp != 0 && packetCount != 0 {
-			return nil, ntpTimestamp, packetCount, nil
-		}
+		p, processed, ntp, count, isSenderReport, err := unmarshal(rawData)
 		if err != nil {
 			return nil, 0, 0, err
 		}
 
+		if isSenderReport && !seenSenderReport {
+			ntpTimestamp, packetCount = ntp, count
+			seenSenderReport = true
+		}
+
 		packets = append(packets, p)
 		rawData = rawData[processed:]
 	}
@@ -41,15 +56,339 @@ func Unmarshal(rawData []byte) ([]Packet, uint64, uint32, error) {
 		return nil, 0, 0, errInvalidHeader
 	// Multiple Packets
 	default:
-		return packets, 0, 0, nil
+		return packets, ntpTimestamp, packetCount, nil
+	}
+}
+
+// UnmarshalWithRaw behaves like Unmarshal, but every packet in the datagram is
+// returned, along with the exact bytes each packet was decoded from. The
+// returned byte slices alias rawData, and raw[i] is the wire form of
+// packets[i], so relays can forward the original bytes without re-marshaling.
+func UnmarshalWithRaw(rawData []byte) (packets []Packet, raw [][]byte, err error) {
+	for len(rawData) != 0 {
+		p, processed, _, _, _, err := unmarshal(rawData)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		packets = append(packets, p)
+		raw = append(raw, rawData[:processed:processed])
+		rawData = rawData[processed:]
+	}
+
+	if len(packets) == 0 {
+		return nil, nil, errInvalidHeader
+	}
+
+	return packets, raw, nil
+}
+
+// UnmarshalWithValidation decodes a udp datagram like Unmarshal does for
+// every packet, and also checks the packets against the RFC 3550 compound
+// packet rules of CompoundPacket.Validate. err is a parse error, in which
+// case no packets are returned. validationErr is nil if the datagram is a
+// compliant compound packet; otherwise it says why not, and the parsed
+// packets are still returned so relays can decide to forward them anyway.
+// Reduced-size datagrams (RFC 5506) are reported as non-compliant.
+func UnmarshalWithValidation(rawData []byte) (packets []Packet, err error, validationErr error) { //nolint:golint,stylecheck
+	packets, err = UnmarshalOptions{}.Unmarshal(rawData)
+	if err != nil {
+		return nil, err, nil
+	}
+	return packets, nil, CompoundPacket(packets).Validate()
+}
+
+// UnmarshalAt decodes a udp datagram like UnmarshalOptions.Unmarshal and
+// stamps every SenderReport and ReceiverReport in it with arrival, the
+// time the datagram was received, so RTT computations such as
+// RoundTripTime need no bookkeeping alongside the packets.
+func UnmarshalAt(rawData []byte, arrival time.Time) ([]Packet, error) {
+	packets, err := UnmarshalOptions{}.Unmarshal(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range packets {
+		switch p := p.(type) {
+		case *SenderReport:
+			p.Arrival = arrival
+		case *ReceiverReport:
+			p.Arrival = arrival
+		}
+	}
+	return packets, nil
+}
+
+// UnmarshalFirst decodes only the first packet of a udp datagram and
+// returns it along with the number of bytes it occupied, leaving the rest
+// of the datagram unparsed. Routers that dispatch on the leading packet
+// can use it to skip decoding the whole compound; rawData[n:] holds the
+// remaining packets.
+func UnmarshalFirst(rawData []byte) (Packet, int, error) {
+	if len(rawData) == 0 {
+		return nil, 0, errInvalidHeader
+	}
+
+	p, processed, _, _, _, err := unmarshal(rawData)
+	if err != nil {
+		return nil, 0, err
+	}
+	return p, processed, nil
+}
+
+// UnmarshalType decodes rawData, which must hold exactly one RTCP packet
+// of type t, such as a reduced-size feedback packet that the caller has
+// already routed by type, without going through the compound datagram
+// handling of Unmarshal. The concrete type of the returned Packet is the
+// one Unmarshal would use for these bytes. errWrongType is returned if
+// the header is of a different type, and errTrailingData if rawData
+// continues past the packet.
+func UnmarshalType(t PacketType, rawData []byte) (Packet, error) {
+	var h Header
+	if err := h.Unmarshal(rawData); err != nil {
+		return nil, err
+	}
+	if h.Type != t {
+		return nil, fmt.Errorf("%w: got %v, want %v", errWrongType, h.Type, t)
+	}
+
+	p, processed, _, _, _, err := unmarshal(rawData)
+	if err != nil {
+		return nil, err
+	}
+	if processed != len(rawData) {
+		return nil, errTrailingData
+	}
+	return p, nil
+}
+
+// UnmarshalBatch decodes each of datagrams independently, as
+// UnmarshalOptions{}.Unmarshal does, for tools that process capture files.
+// The results are returned in parallel slices: packets[i] and errs[i] are
+// the outcome for datagrams[i], and exactly one of them is non-nil. A
+// datagram that fails to decode does not stop the batch.
+func UnmarshalBatch(datagrams [][]byte) ([][]Packet, []error) {
+	packets := make([][]Packet, len(datagrams))
+	errs := make([]error, len(datagrams))
+	for i, d := range datagrams {
+		packets[i], errs[i] = UnmarshalOptions{}.Unmarshal(d)
+	}
+	return packets, errs
+}
+
+// UnmarshalOptions configures how UnmarshalOptions.Unmarshal decodes a
+// datagram. The zero value decodes every packet and fails on any error.
+type UnmarshalOptions struct {
+	// AllowTruncated stops decoding at a packet whose header declares more
+	// bytes than remain in the datagram, instead of failing. The packets
+	// decoded so far are returned, followed by a *TruncatedPacket holding
+	// the remaining bytes, so monitoring tools can count truncation.
+	AllowTruncated bool
+
+	// AllowMisaligned ignores 1 to 3 trailing bytes left after the last
+	// packet, as emitted by senders that do not keep the datagram 32-bit
+	// aligned. By default such a datagram is rejected with
+	// errMisalignedPacket.
+	AllowMisaligned bool
+
+	// AlwaysCompound returns the decoded packets wrapped in a single
+	// *CompoundPacket, even when the datagram is a reduced-size packet
+	// holding one feedback message, so callers can handle every datagram
+	// the same way.
+	AlwaysCompound bool
+}
+
+// Unmarshal takes an entire udp datagram (which may consist of multiple RTCP
+// packets) and returns every packet it contains, decoded according to o.
+func (o UnmarshalOptions) Unmarshal(rawData []byte) ([]Packet, error) {
+	var packets []Packet
+	for len(rawData) != 0 {
+		if len(packets) != 0 && len(rawData) < headerLength {
+			if o.AllowMisaligned {
+				break
+			}
+			return nil, errMisalignedPacket
+		}
+
+		if o.AllowTruncated && isTruncated(rawData) {
+			truncated := TruncatedPacket(rawData)
+			packets = append(packets, &truncated)
+			break
+		}
+
+		p, processed, _, _, _, err := unmarshal(rawData)
+		if err != nil {
+			return nil, err
+		}
+
+		packets = append(packets, p)
+		rawData = rawData[processed:]
+	}
+
+	if len(packets) == 0 {
+		return nil, errInvalidHeader
+	}
+
+	if o.AlwaysCompound {
+		compound := CompoundPacket(packets)
+		return []Packet{&compound}, nil
+	}
+	return packets, nil
+}
+
+// Iterate walks the packets in a udp datagram without decoding them. fn is
+// called with the type and the exact bytes of each packet, in order; the
+// byte slice aliases rawData. Iteration ends early when fn returns true or
+// a non-nil error, and that error is returned. Callers that only care about
+// some packet types can Unmarshal just those, skipping the cost of
+// decoding the rest.
+func Iterate(rawData []byte, fn func(PacketType, []byte) (stop bool, err error)) error {
+	if len(rawData) == 0 {
+		return errInvalidHeader
+	}
+
+	for len(rawData) != 0 {
+		var h Header
+		if err := h.Unmarshal(rawData); err != nil {
+			return err
+		}
+
+		n := int(h.Length+1) * 4
+		if n > len(rawData) {
+			return errPacketTooShort
+		}
+
+		stop, err := fn(h.Type, rawData[:n:n])
+		if stop || err != nil {
+			return err
+		}
+		rawData = rawData[n:]
+	}
+
+	return nil
+}
+
+// isTruncated reports whether the first packet in rawData has a valid
+// header whose length field runs past the end of rawData.
+func isTruncated(rawData []byte) bool {
+	var h Header
+	if err := h.Unmarshal(rawData); err != nil {
+		return false
+	}
+	return int(h.Length+1)*4 > len(rawData)
+}
+
+// Dump decodes a udp datagram and renders it as an indented tree for
+// command line tools: the datagram, then each packet it holds, then each
+// packet's report blocks, SDES items or XR blocks as printed by the
+// packet's String method.
+func Dump(rawData []byte) (string, error) {
+	packets, err := UnmarshalOptions{}.Unmarshal(rawData)
+	if err != nil {
+		return "", err
+	}
+
+	out := fmt.Sprintf("Datagram: %d bytes, %d packets\n", len(rawData), len(packets))
+	for _, p := range packets {
+		var s string
+		if stringer, ok := p.(fmt.Stringer); ok {
+			s = stringer.String()
+		} else {
+			s = stringify(p)
+		}
+		out += "\t" + strings.TrimSuffix(strings.ReplaceAll(s, "\n", "\n\t"), "\t")
+	}
+	return out, nil
+}
+
+// SemanticEqual reports whether a and b marshal to the same bytes once
+// fields whose order carries no meaning are normalized: the SSRC list of
+// a ReceiverEstimatedMaximumBitrate and the NackPairs of a
+// TransportLayerNack. Neither packet is modified. Packets that fail to
+// marshal are never equal.
+func SemanticEqual(a, b Packet) bool {
+	da, err := a.Marshal()
+	if err != nil {
+		return false
+	}
+	db, err := b.Marshal()
+	if err != nil {
+		return false
+	}
+	if string(da) == string(db) {
+		return true
+	}
+
+	da, err = normalized(a).Marshal()
+	if err != nil {
+		return false
+	}
+	db, err = normalized(b).Marshal()
+	if err != nil {
+		return false
 	}
+	return string(da) == string(db)
+}
+
+// normalized returns a normalized copy of p, or p itself if its type has
+// nothing to normalize.
+func normalized(p Packet) Packet {
+	switch p := p.(type) {
+	case *ReceiverEstimatedMaximumBitrate:
+		c := *p
+		c.SSRCs = append([]uint32(nil), p.SSRCs...)
+		c.Normalize()
+		return &c
+	case *TransportLayerNack:
+		c := *p
+		c.Normalize()
+		return &c
+	}
+	return p
 }
 
 // Marshal takes an array of Packets and serializes them to a single buffer
 func Marshal(packets []Packet) ([]byte, error) {
+	return DefaultMarshalOptions().Marshal(packets)
+}
+
+// MarshalOptions configures how MarshalOptions.Marshal serializes packets.
+type MarshalOptions struct {
+	// RecomputeCounts derives header fields that duplicate the contents of a
+	// packet, such as the count and length in the stored Header of a
+	// TransportLayerCC or CCFeedbackReport, from the packet itself before
+	// serializing, so stale
+	// values left behind by direct mutation are never written. Packet types
+	// that do not store a Header always derive these fields.
+	RecomputeCounts bool
+
+	// PreserveReserved writes the reserved bits kept by Unmarshal instead
+	// of zeroing them, so relays forward FCI fields defined by later
+	// revisions unchanged. Of the FCI formats decoded by this package only
+	// FullIntraRequest has reserved bits; PLI carries no FCI and SLI uses
+	// all of its bits.
+	PreserveReserved bool
+}
+
+// DefaultMarshalOptions returns the options used by Marshal.
+func DefaultMarshalOptions() MarshalOptions {
+	return MarshalOptions{RecomputeCounts: true}
+}
+
+// Marshal serializes packets to a single buffer according to o.
+func (o MarshalOptions) Marshal(packets []Packet) ([]byte, error) {
 	out := make([]byte, 0)
 	for _, p := range packets {
-		data, err := p.Marshal()
+		if o.RecomputeCounts {
+			p = recomputeCounts(p)
+		}
+		var data []byte
+		var err error
+		if fir, ok := p.(*FullIntraRequest); ok && o.PreserveReserved {
+			data, err = fir.marshal(true)
+		} else {
+			data, err = p.Marshal()
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -92,53 +431,98 @@ func unmarshal(rawData []byte) (packet Packet, bytesprocessed int, ntpTimestamp
 			packet = new(TransportLayerNack)
 		case FormatRRR:
 			packet = new(RapidResynchronizationRequest)
+		case FormatECN:
+			packet = new(ECNFeedback)
 		case FormatTCC:
 			packet = new(TransportLayerCC)
 		default:
-			packet = new(RawPacket)
+			packet = newUnknownFeedback(h)
 		}
 
 	case TypePayloadSpecificFeedback:
 		switch h.Count {
 		case FormatPLI:
-			packet = new(PictureLossIndication)
+			// A PLI has no FCI, so a body that carries one is most
+			// likely a NACK sent under the wrong packet type.
+			if n, err := contentLength(h, inPacket); err == nil && n > headerLength+ssrcLength*2 {
+				logf("rtcp: %v packet with FMT %d carries FCI, which a PLI does not have", h.Type, h.Count)
+				packet = newUnknownPacket(h)
+			} else {
+				packet = new(PictureLossIndication)
+			}
 		case FormatSLI:
 			packet = new(SliceLossIndication)
 		case FormatREMB:
-			packet = new(ReceiverEstimatedMaximumBitrate)
+			// FMT 15 is Application Layer Feedback, of which REMB is
+			// only one kind. Anything else is left unparsed.
+			if hasREMBIdentifier(inPacket) {
+				packet = new(ReceiverEstimatedMaximumBitrate)
+			} else {
+				packet = newUnknownPacket(h)
+			}
 		case FormatFIR:
 			packet = new(FullIntraRequest)
 		default:
-			packet = new(RawPacket)
+			packet = newUnknownFeedback(h)
 		}
 
 	case TypeExtendedReport:
 		packet = new(ExtendedReport)
 
 	default:
-		packet = new(RawPacket)
+		packet = newUnknownPacket(h)
 	}
 
 	isSender := false
 
-	if h.Type == TypeSenderReport {
-		senderReport := new(SenderReport)
-		err_senderReport := senderReport.Unmarshal(inPacket)
-
-		if err_senderReport != nil {
-			log.Println(err_senderReport)
-		}
+	observer := loadParseObserver()
+	var start time.Time
+	if observer != nil {
+		start = time.Now()
+	}
 
-		ntpTimestamp = senderReport.NTPTime
-		packetCount = senderReport.PacketCount
+	err = packet.Unmarshal(inPacket)
+	if err != nil {
+		logf("rtcp: failed to decode %v packet: %v", h.Type, err)
+	} else if sr, ok := packet.(*SenderReport); ok {
+		ntpTimestamp = sr.NTPTime
+		packetCount = sr.PacketCount
 		isSender = true
-		err = packet.Unmarshal(inPacket)
+	}
 
-	} else {
-		err = packet.Unmarshal(inPacket)
-		ntpTimestamp = 0
-		packetCount = 0
+	if observer != nil {
+		observer(h.Type, bytesprocessed, time.Since(start))
 	}
 
 	return packet, bytesprocessed, ntpTimestamp, packetCount, isSender, err
 }
+
+// newUnknownFeedback is newUnknownPacket for feedback packets. It also logs
+// a warning when the packet is left as a RawPacket because its FMT is not
+// assigned for its type, such as a TWCC FMT under the payload-specific
+// feedback type.
+func newUnknownFeedback(h Header) Packet {
+	packet := newUnknownPacket(h)
+	if _, ok := packet.(*RawPacket); ok {
+		if err := ValidateFMT(h.Type, h.Count); err != nil {
+			logf("rtcp: %v packet with FMT %d left undecoded: %v", h.Type, h.Count, err)
+		}
+	}
+	return packet
+}
+
+// recomputeCounts returns p, or a copy of p whose stored header has been
+// rebuilt from its contents.
+func recomputeCounts(p Packet) Packet {
+	switch t := p.(type) {
+	case *TransportLayerCC:
+		c := *t
+		c.Header = c.computeHeader()
+		return &c
+	case *CCFeedbackReport:
+		c := *t
+		c.Header = c.computeHeader()
+		return &c
+	}
+	return p
+}
@@ -23,6 +23,8 @@ import (
 
 	- If no fmt string is present, "%+v" is used by default
 
+	- Fields tagged `fmt:"-"` are left out
+
 	The intention of this stringify() function is to simplify creation
 	of String() methods on new packet types, as it provides a simple
 	baseline implementation that works well in the majority of cases.
@@ -65,6 +67,9 @@ func formatField(name string, format string, f interface{}, indent string) strin
 		for i := 0; i < value.NumField(); i++ {
 			if value.Field(i).CanInterface() {
 				format = value.Type().Field(i).Tag.Get("fmt")
+				if format == "-" {
+					continue
+				}
 				if format == "" {
 					format = "%+v"
 				}
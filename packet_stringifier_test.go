@@ -250,9 +250,11 @@ func TestPrint(t *testing.T) {
 				"\t\t0:\n" +
 				"\t\t\tSSRC: 305419896\n" +
 				"\t\t\tSequenceNumber: 66\n" +
+				"\t\t\tReserved: 0\n" +
 				"\t\t1:\n" +
 				"\t\t\tSSRC: 2557891634\n" +
-				"\t\t\tSequenceNumber: 87\n",
+				"\t\t\tSequenceNumber: 87\n" +
+				"\t\t\tReserved: 0\n",
 		},
 		{
 			&Goodbye{
@@ -127,3 +127,520 @@ func TestInvalidHeaderLength(t *testing.T) {
 		t.Fatalf("Unmarshal(nil) err = %v, want %v", got, want)
 	}
 }
+
+func TestUnmarshalWithRaw(t *testing.T) {
+	data := realPacket()
+
+	packets, raw, err := UnmarshalWithRaw(data)
+	if err != nil {
+		t.Fatalf("Error unmarshalling packets: %s", err)
+	}
+
+	if got, want := len(raw), len(packets); got != want {
+		t.Fatalf("len(raw) = %d, want %d", got, want)
+	}
+
+	// Segment boundaries of realPacket()
+	offsets := []int{0, 32, 84, 92, 104, len(data)}
+	for i := range packets {
+		assert.Equal(t, data[offsets[i]:offsets[i+1]], raw[i], "raw[%d]", i)
+	}
+
+	// Re-marshaling the Goodbye adds an empty reason, so only the
+	// retained bytes reproduce the original wire form.
+	marshaled, err := packets[2].Marshal()
+	assert.NoError(t, err)
+	assert.NotEqual(t, raw[2], marshaled)
+
+	_, _, err = UnmarshalWithRaw(nil)
+	if got, want := err, errInvalidHeader; !errors.Is(got, want) {
+		t.Fatalf("UnmarshalWithRaw(nil) err = %v, want %v", got, want)
+	}
+}
+
+func TestUnmarshalOptionsAllowTruncated(t *testing.T) {
+	data := realPacket()[:92]
+	// Inflate the Goodbye's length from 1 to 5 words
+	data[84+3] = 0x5
+
+	_, err := UnmarshalOptions{}.Unmarshal(data)
+	if got, want := err, errPacketTooShort; !errors.Is(got, want) {
+		t.Fatalf("Unmarshal err = %v, want %v", got, want)
+	}
+
+	packets, err := UnmarshalOptions{AllowTruncated: true}.Unmarshal(data)
+	assert.NoError(t, err)
+	if !assert.Len(t, packets, 3) {
+		return
+	}
+
+	assert.IsType(t, &ReceiverReport{}, packets[0])
+	assert.IsType(t, &SourceDescription{}, packets[1])
+
+	truncated, ok := packets[2].(*TruncatedPacket)
+	if !ok {
+		t.Fatalf("packets[2] = %T, want *TruncatedPacket", packets[2])
+	}
+	assert.Equal(t, TruncatedPacket(data[84:]), *truncated)
+	assert.Equal(t, TypeGoodbye, truncated.Header().Type)
+}
+
+func TestMarshalOptionsRecomputeCounts(t *testing.T) {
+	twcc := &TransportLayerCC{
+		// Stale header left over from a packet with fewer deltas
+		Header: Header{
+			Count:  FormatTCC,
+			Type:   TypeTransportSpecificFeedback,
+			Length: 4,
+		},
+		SenderSSRC:         4195875351,
+		MediaSSRC:          1124282272,
+		BaseSequenceNumber: 153,
+		PacketStatusCount:  3,
+		ReferenceTime:      4057090,
+		FbPktCount:         23,
+		PacketChunks: []PacketStatusChunk{
+			&RunLengthChunk{
+				Type:               TypeTCCRunLengthChunk,
+				PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta,
+				RunLength:          3,
+			},
+		},
+		RecvDeltas: []*RecvDelta{
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 37000},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 37000},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 37000},
+		},
+	}
+	rr := &ReceiverReport{SSRC: 0x902f9e2e}
+	// Reports appended after construction
+	rr.Reports = append(rr.Reports, ReceptionReport{SSRC: 0xbc5e9a40}, ReceptionReport{SSRC: 0xbc5e9a41})
+
+	data, err := Marshal([]Packet{rr, twcc})
+	assert.NoError(t, err)
+
+	packets, err := UnmarshalOptions{}.Unmarshal(data)
+	assert.NoError(t, err)
+	if !assert.Len(t, packets, 2) {
+		return
+	}
+	assert.Len(t, packets[0].(*ReceiverReport).Reports, 2)
+	got := packets[1].(*TransportLayerCC).Header
+	assert.Equal(t, Header{
+		Padding: true,
+		Count:   FormatTCC,
+		Type:    TypeTransportSpecificFeedback,
+		Length:  6,
+	}, got)
+	// The caller's packet is left untouched
+	assert.Equal(t, uint16(4), twcc.Header.Length)
+
+	data, err = MarshalOptions{}.Marshal([]Packet{twcc})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(4), data[3])
+}
+
+func TestMarshalOptionsRecomputeCountsCCFB(t *testing.T) {
+	ccfb := &CCFeedbackReport{
+		// Stale header left over from a report with no blocks
+		Header:          Header{Count: FormatCCFB, Type: TypeTransportSpecificFeedback, Length: 2},
+		SenderSSRC:      1,
+		ReportTimestamp: 1,
+	}
+	// Block appended after construction
+	ccfb.ReportBlocks = append(ccfb.ReportBlocks, CCFeedbackReportBlock{
+		MediaSSRC:     2,
+		BeginSequence: 3,
+		MetricBlocks:  []CCFeedbackMetricBlock{{Received: true, ECN: ECNCE, ArrivalTimeOffset: 1}},
+	})
+
+	data, err := Marshal([]Packet{ccfb})
+	assert.NoError(t, err)
+	assert.Len(t, data, 24)
+
+	var got CCFeedbackReport
+	assert.NoError(t, got.Unmarshal(data))
+	assert.Equal(t, Header{Count: FormatCCFB, Type: TypeTransportSpecificFeedback, Length: 5}, got.Header)
+	assert.Equal(t, ccfb.ReportBlocks, got.ReportBlocks)
+	// The caller's packet is left untouched
+	assert.Equal(t, uint16(2), ccfb.Header.Length)
+}
+
+func TestUnmarshalOptionsAllowMisaligned(t *testing.T) {
+	// A PLI followed by two stray bytes
+	data := append(realPacket()[92:104], 0x00, 0x00)
+
+	_, err := UnmarshalOptions{}.Unmarshal(data)
+	if got, want := err, errMisalignedPacket; !errors.Is(got, want) {
+		t.Fatalf("Unmarshal err = %v, want %v", got, want)
+	}
+
+	packets, err := UnmarshalOptions{AllowMisaligned: true}.Unmarshal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{&PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x902f9e2e}}, packets)
+
+	// Stray bytes on their own are not a packet in either mode
+	_, err = UnmarshalOptions{AllowMisaligned: true}.Unmarshal([]byte{0x81, 0xc9})
+	if got, want := err, errPacketTooShort; !errors.Is(got, want) {
+		t.Fatalf("Unmarshal err = %v, want %v", got, want)
+	}
+}
+
+func TestIterate(t *testing.T) {
+	// A large compound: RR and SDES, many NACKs, a PLI, then many more NACKs
+	packets := []Packet{
+		&ReceiverReport{SSRC: 0x902f9e2e},
+		NewCNAMESourceDescription(0x902f9e2e, "cname"),
+	}
+	for i := 0; i < 50; i++ {
+		packets = append(packets, &TransportLayerNack{MediaSSRC: uint32(i), Nacks: []NackPair{{PacketID: uint16(i)}}})
+	}
+	packets = append(packets, &PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x4bc4fcb4})
+	for i := 0; i < 50; i++ {
+		packets = append(packets, &TransportLayerNack{MediaSSRC: uint32(i), Nacks: []NackPair{{PacketID: uint16(i)}}})
+	}
+	data, err := Marshal(packets)
+	assert.NoError(t, err)
+
+	var (
+		visited int
+		pli     PictureLossIndication
+	)
+	err = Iterate(data, func(typ PacketType, raw []byte) (bool, error) {
+		visited++
+		var h Header
+		if err := h.Unmarshal(raw); err != nil {
+			return true, err
+		}
+		if typ != TypePayloadSpecificFeedback || h.Count != FormatPLI {
+			return false, nil
+		}
+		return true, pli.Unmarshal(raw)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 53, visited)
+	assert.Equal(t, PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x4bc4fcb4}, pli)
+
+	errStop := errors.New("stop")
+	err = Iterate(data, func(PacketType, []byte) (bool, error) { return false, errStop })
+	assert.True(t, errors.Is(err, errStop))
+
+	var types []PacketType
+	err = Iterate(realPacket(), func(typ PacketType, raw []byte) (bool, error) {
+		types = append(types, typ)
+		return false, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []PacketType{
+		TypeReceiverReport,
+		TypeSourceDescription,
+		TypeGoodbye,
+		TypePayloadSpecificFeedback,
+		TypeTransportSpecificFeedback,
+	}, types)
+
+	err = Iterate(realPacket()[:90], func(PacketType, []byte) (bool, error) { return false, nil })
+	assert.True(t, errors.Is(err, errPacketTooShort))
+}
+
+func TestUnmarshalEmptyPackets(t *testing.T) {
+	// An RR with no report blocks is the smallest packet a receiver sends
+	packets, _, _, err := Unmarshal([]byte{0x80, 0xc9, 0x00, 0x01, 0x90, 0x2f, 0x9e, 0x2e})
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{&ReceiverReport{SSRC: 0x902f9e2e, ProfileExtensions: []byte{}}}, packets)
+
+	// SDES and BYE with a zero count have no body at all
+	packets, err = UnmarshalOptions{}.Unmarshal([]byte{
+		0x80, 0xc9, 0x00, 0x01, 0x90, 0x2f, 0x9e, 0x2e,
+		0x80, 0xca, 0x00, 0x00,
+		0x80, 0xcb, 0x00, 0x00,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{
+		&ReceiverReport{SSRC: 0x902f9e2e, ProfileExtensions: []byte{}},
+		&SourceDescription{},
+		&Goodbye{Sources: []uint32{}},
+	}, packets)
+
+	// Packets that require a sender SSRC cannot be empty
+	for _, data := range [][]byte{
+		{0x80, 0xc8, 0x00, 0x00},
+		{0x80, 0xc9, 0x00, 0x00},
+		{0x80, 0xcf, 0x00, 0x00},
+	} {
+		_, err := UnmarshalOptions{}.Unmarshal(data)
+		assert.ErrorIs(t, err, errPacketTooShort, "type %d", data[1])
+	}
+}
+
+func TestUnmarshalOptionsAlwaysCompound(t *testing.T) {
+	pli := realPacket()[92:104]
+	want := &PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x902f9e2e}
+
+	packets, err := UnmarshalOptions{}.Unmarshal(pli)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{want}, packets)
+
+	packets, err = UnmarshalOptions{AlwaysCompound: true}.Unmarshal(pli)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{&CompoundPacket{want}}, packets)
+
+	packets, err = UnmarshalOptions{AlwaysCompound: true}.Unmarshal(realPacket())
+	assert.NoError(t, err)
+	if assert.Len(t, packets, 1) {
+		compound, ok := packets[0].(*CompoundPacket)
+		if assert.True(t, ok, "got %T, want *CompoundPacket", packets[0]) {
+			assert.Len(t, *compound, 5)
+		}
+	}
+}
+
+func TestDump(t *testing.T) {
+	data, err := Marshal([]Packet{
+		&SenderReport{
+			SSRC:    0x902f9e2e,
+			NTPTime: 0xda8bd1fcdddda05a,
+			Reports: []ReceptionReport{
+				{SSRC: 0xbc5e9a40, FractionLost: 1, TotalLost: 2, LastSequenceNumber: 0x46e1},
+			},
+		},
+		&SourceDescription{Chunks: []SourceDescriptionChunk{{
+			Source: 0x902f9e2e,
+			Items: []SourceDescriptionItem{
+				{Type: SDESCNAME, Text: "cname"},
+				{Type: SDESTool, Text: "pion"},
+			},
+		}}},
+	})
+	assert.NoError(t, err)
+
+	out, err := Dump(data)
+	assert.NoError(t, err)
+	for _, want := range []string{
+		"Datagram: 76 bytes, 2 packets\n",
+		"\tSenderReport from 902f9e2e\n",
+		"\t\tbc5e9a40\t1/2\t18145\n",
+		"\tSource Description:\n",
+		"\t\t902f9e2e:\n",
+		"\t\t\tCNAME: cname\n",
+		"\t\t\tTOOL: pion\n",
+	} {
+		assert.Contains(t, out, want)
+	}
+
+	_, err = Dump(nil)
+	assert.ErrorIs(t, err, errInvalidHeader)
+}
+
+func TestUnmarshalFirst(t *testing.T) {
+	data, err := Marshal([]Packet{
+		&ReceiverReport{SSRC: 0x902f9e2e},
+		NewCNAMESourceDescription(0x902f9e2e, "cname"),
+		&PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x902f9e2e},
+	})
+	assert.NoError(t, err)
+
+	p, n, err := UnmarshalFirst(data)
+	assert.NoError(t, err)
+	assert.Equal(t, &ReceiverReport{SSRC: 0x902f9e2e, ProfileExtensions: []byte{}}, p)
+	assert.Equal(t, 8, n)
+
+	p, _, err = UnmarshalFirst(data[n:])
+	assert.NoError(t, err)
+	assert.IsType(t, &SourceDescription{}, p)
+
+	_, _, err = UnmarshalFirst(nil)
+	assert.ErrorIs(t, err, errInvalidHeader)
+}
+
+func TestUnmarshalWithValidation(t *testing.T) {
+	compliant, err := Marshal([]Packet{
+		&ReceiverReport{SSRC: 0x902f9e2e},
+		NewCNAMESourceDescription(0x902f9e2e, "cname"),
+		&PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x902f9e2e},
+	})
+	assert.NoError(t, err)
+
+	packets, err, validationErr := UnmarshalWithValidation(compliant)
+	assert.NoError(t, err)
+	assert.NoError(t, validationErr)
+	assert.Len(t, packets, 3)
+
+	// Feedback before the CNAME parses fine but breaks the compound rules.
+	noncompliant, err := Marshal([]Packet{
+		&ReceiverReport{SSRC: 0x902f9e2e},
+		&PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x902f9e2e},
+	})
+	assert.NoError(t, err)
+
+	packets, err, validationErr = UnmarshalWithValidation(noncompliant)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, validationErr, errPacketBeforeCNAME)
+	assert.Len(t, packets, 2)
+
+	packets, err, validationErr = UnmarshalWithValidation(compliant[:len(compliant)-1])
+	assert.Error(t, err)
+	assert.NoError(t, validationErr)
+	assert.Nil(t, packets)
+}
+
+func TestUnmarshalBatch(t *testing.T) {
+	pli, err := (&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}).Marshal()
+	assert.NoError(t, err)
+
+	datagrams := [][]byte{
+		realPacket(),
+		nil,
+		pli,
+		realPacket()[:40],
+		append(pli, 0x80),
+	}
+
+	packets, errs := UnmarshalBatch(datagrams)
+	assert.Len(t, packets, len(datagrams))
+	assert.Len(t, errs, len(datagrams))
+
+	want, err := UnmarshalOptions{}.Unmarshal(realPacket())
+	assert.NoError(t, err)
+	assert.Equal(t, want, packets[0])
+	assert.NoError(t, errs[0])
+
+	assert.Nil(t, packets[1])
+	assert.True(t, errors.Is(errs[1], errInvalidHeader))
+
+	assert.Equal(t, []Packet{&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}}, packets[2])
+	assert.NoError(t, errs[2])
+
+	assert.Nil(t, packets[3])
+	assert.True(t, errors.Is(errs[3], errPacketTooShort))
+
+	assert.Nil(t, packets[4])
+	assert.True(t, errors.Is(errs[4], errMisalignedPacket))
+}
+
+func TestUnmarshalMultipleSenderReports(t *testing.T) {
+	first := &SenderReport{
+		SSRC:        0x902f9e2e,
+		NTPTime:     0xda8bd1fcdddda05a,
+		RTPTime:     0xaaf4edd5,
+		PacketCount: 10,
+		OctetCount:  1000,
+	}
+	second := &SenderReport{
+		SSRC:        0xbc5e9a40,
+		NTPTime:     0xda8bd1fd00000000,
+		RTPTime:     0x00001000,
+		PacketCount: 20,
+		OctetCount:  2000,
+	}
+	sdes := NewCNAMESourceDescription(0x902f9e2e, "mixer")
+
+	data, err := Marshal([]Packet{first, second, sdes})
+	assert.NoError(t, err)
+
+	packets, ntpTimestamp, packetCount, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, first.NTPTime, ntpTimestamp)
+	assert.Equal(t, first.PacketCount, packetCount)
+	if !assert.Len(t, packets, 3) {
+		return
+	}
+
+	for i, want := range []*SenderReport{first, second} {
+		sr, ok := packets[i].(*SenderReport)
+		if !assert.True(t, ok, "packet %d is %T", i, packets[i]) {
+			continue
+		}
+		assert.Equal(t, want.SSRC, sr.SSRC)
+		assert.Equal(t, want.NTPTime, sr.NTPTime)
+		assert.Equal(t, want.PacketCount, sr.PacketCount)
+	}
+	assert.Equal(t, sdes, packets[2])
+}
+
+func TestUnmarshalType(t *testing.T) {
+	want := &SenderReport{
+		SSRC:        0x902f9e2e,
+		NTPTime:     0xda8bd1fcdddda05a,
+		RTPTime:     0xaaf4edd5,
+		PacketCount: 1,
+		OctetCount:  2,
+	}
+	data, err := want.Marshal()
+	assert.NoError(t, err)
+
+	p, err := UnmarshalType(TypeSenderReport, data)
+	assert.NoError(t, err)
+	sr, ok := p.(*SenderReport)
+	if assert.True(t, ok, "got %T, want *SenderReport", p) {
+		assert.Equal(t, want.SSRC, sr.SSRC)
+		assert.Equal(t, want.NTPTime, sr.NTPTime)
+		assert.Equal(t, want.OctetCount, sr.OctetCount)
+	}
+
+	_, err = UnmarshalType(TypeReceiverReport, data)
+	assert.True(t, errors.Is(err, errWrongType))
+
+	_, err = UnmarshalType(TypeSenderReport, realPacket())
+	assert.True(t, errors.Is(err, errWrongType))
+
+	_, err = UnmarshalType(TypeReceiverReport, realPacket())
+	assert.True(t, errors.Is(err, errTrailingData))
+
+	_, err = UnmarshalType(TypeSenderReport, data[:len(data)-4])
+	assert.True(t, errors.Is(err, errPacketTooShort))
+}
+
+func TestUnmarshalWrongFeedbackType(t *testing.T) {
+	l := &recordingLogger{}
+	SetLogger(l)
+	defer SetLogger(nil)
+
+	// A NACK, FMT 1, sent under the payload-specific feedback type, where
+	// FMT 1 is PLI.
+	nack := []byte{
+		// v=2, p=0, FMT=1, PSFB, len=3
+		0x81, 0xce, 0x0, 0x3,
+		// sender=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// media=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// nack 0xAAAA, 0x5555
+		0xaa, 0xaa, 0x55, 0x55,
+	}
+	packets, _, _, err := Unmarshal(nack)
+	assert.NoError(t, err)
+	if assert.Len(t, packets, 1) {
+		raw, ok := packets[0].(*RawPacket)
+		assert.True(t, ok, "got %T, want *RawPacket", packets[0])
+		if ok {
+			assert.Equal(t, RawPacket(nack), *raw)
+		}
+	}
+	assert.Equal(t, []string{"rtcp: PSFB packet with FMT 1 carries FCI, which a PLI does not have"}, l.messages)
+
+	// The CCFB FMT of RFC 8888 is not assigned for payload-specific
+	// feedback at all.
+	l.messages = nil
+	ccfb := []byte{
+		// v=2, p=0, FMT=11, PSFB, len=2
+		0x8b, 0xce, 0x0, 0x2,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0x90, 0x2f, 0x9e, 0x2e,
+	}
+	packets, _, _, err = Unmarshal(ccfb)
+	assert.NoError(t, err)
+	if assert.Len(t, packets, 1) {
+		_, ok := packets[0].(*RawPacket)
+		assert.True(t, ok, "got %T, want *RawPacket", packets[0])
+	}
+	assert.Equal(t, []string{"rtcp: PSFB packet with FMT 11 left undecoded: " + errWrongFeedbackType.Error()}, l.messages)
+
+	// A genuine PLI still decodes without a warning.
+	l.messages = nil
+	pli := append([]byte{0x81, 0xce, 0x0, 0x2}, nack[4:12]...)
+	packets, _, _, err = Unmarshal(pli)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{&PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x902f9e2e}}, packets)
+	assert.Empty(t, l.messages)
+}
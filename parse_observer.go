@@ -0,0 +1,34 @@
+package rtcp
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ParseObserver is called once for every packet the unmarshaler decodes,
+// with the packet type, the packet length in bytes and the time spent
+// decoding it.
+type ParseObserver func(t PacketType, bytes int, dur time.Duration)
+
+// parseObserverHolder wraps the observer so that atomic.Value, which
+// cannot store nil, can hold an unset observer.
+type parseObserverHolder struct {
+	fn ParseObserver
+}
+
+//nolint:gochecknoglobals
+var parseObserver atomic.Value
+
+// SetParseObserver installs fn as the ParseObserver used by Unmarshal and
+// the other parsing functions of this package. It is meant for profiling:
+// when no observer is set the unmarshaler does not read the clock. A nil
+// fn removes the observer. The observer may be called concurrently from
+// every goroutine that parses packets.
+func SetParseObserver(fn func(t PacketType, bytes int, dur time.Duration)) {
+	parseObserver.Store(parseObserverHolder{fn: fn})
+}
+
+func loadParseObserver() ParseObserver {
+	h, _ := parseObserver.Load().(parseObserverHolder)
+	return h.fn
+}
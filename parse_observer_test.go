@@ -0,0 +1,37 @@
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetParseObserver(t *testing.T) {
+	type observation struct {
+		Type  PacketType
+		Bytes int
+	}
+	var got []observation
+	SetParseObserver(func(typ PacketType, bytes int, dur time.Duration) {
+		assert.True(t, dur >= 0)
+		got = append(got, observation{typ, bytes})
+	})
+	defer SetParseObserver(nil)
+
+	_, _, _, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+	assert.Equal(t, []observation{
+		{TypeReceiverReport, 32},
+		{TypeSourceDescription, 52},
+		{TypeGoodbye, 8},
+		{TypePayloadSpecificFeedback, 12},
+		{TypeTransportSpecificFeedback, 12},
+	}, got)
+
+	SetParseObserver(nil)
+	got = nil
+	_, _, _, err = Unmarshal(realPacket())
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -61,6 +61,14 @@ func (p *PictureLossIndication) Unmarshal(rawPacket []byte) error {
 		return errWrongType
 	}
 
+	n, err := contentLength(h, rawPacket)
+	if err != nil {
+		return err
+	}
+	if n < headerLength+ssrcLength*2 {
+		return errPacketTooShort
+	}
+
 	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
 	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
 	return nil
@@ -83,6 +91,20 @@ func (p *PictureLossIndication) String() string {
 	return fmt.Sprintf("PictureLossIndication %x %x", p.SenderSSRC, p.MediaSSRC)
 }
 
+// Reset clears the PictureLossIndication so it can be reused.
+func (p *PictureLossIndication) Reset() {
+	*p = PictureLossIndication{}
+}
+
+// Validate reports likely mistakes in a PictureLossIndication that Marshal
+// accepts, such as a zero media SSRC, which asks no stream for a key frame.
+func (p *PictureLossIndication) Validate() error {
+	if p.MediaSSRC == 0 {
+		return errZeroMediaSSRC
+	}
+	return nil
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (p *PictureLossIndication) DestinationSSRC() []uint32 {
 	return []uint32{p.MediaSSRC}
@@ -30,6 +30,49 @@ func TestPictureLossIndicationUnmarshal(t *testing.T) {
 				MediaSSRC:  0x4bc4fcb4,
 			},
 		},
+		{
+			Name: "padded",
+			Data: []byte{
+				// v=2, p=1, FMT=1, PSFB, len=3
+				0xa1, 0xce, 0x00, 0x03,
+				// ssrc=0x0
+				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// padding
+				0x00, 0x00, 0x00, 0x04,
+			},
+			Want: PictureLossIndication{
+				SenderSSRC: 0x0,
+				MediaSSRC:  0x4bc4fcb4,
+			},
+		},
+		{
+			Name: "padding eats media ssrc",
+			Data: []byte{
+				// v=2, p=1, FMT=1, PSFB, len=2
+				0xa1, 0xce, 0x00, 0x02,
+				// ssrc=0x0
+				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x4bc4fcb4, last octet read as padding count
+				0x4b, 0xc4, 0xfc, 0x04,
+			},
+			WantError: errPacketTooShort,
+		},
+		{
+			Name: "bad padding count",
+			Data: []byte{
+				// v=2, p=1, FMT=1, PSFB, len=3
+				0xa1, 0xce, 0x00, 0x03,
+				// ssrc=0x0
+				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// padding
+				0x00, 0x00, 0x00, 0x00,
+			},
+			WantError: errWrongPadding,
+		},
 		{
 			Name: "packet too short",
 			Data: []byte{
@@ -164,3 +207,37 @@ func TestPictureLossIndicationUnmarshalHeader(t *testing.T) {
 		}
 	}
 }
+
+func TestPictureLossIndicationValidate(t *testing.T) {
+	if err := (&PictureLossIndication{SenderSSRC: 0x1, MediaSSRC: 0x902f9e2e}).Validate(); err != nil {
+		t.Errorf("Validate() err = %v, want nil", err)
+	}
+	if got, want := (&PictureLossIndication{SenderSSRC: 0x1}).Validate(), errZeroMediaSSRC; !errors.Is(got, want) {
+		t.Errorf("Validate() err = %v, want %v", got, want)
+	}
+}
+
+func TestPictureLossIndicationEmptyFCI(t *testing.T) {
+	// A PLI has no FCI, so its minimal form is just the two SSRCs.
+	data := []byte{
+		// v=2, p=0, FMT=1, PSFB, len=2
+		0x81, 0xce, 0x00, 0x02,
+		// ssrc=0x0
+		0x00, 0x00, 0x00, 0x00,
+		// ssrc=0x4bc4fcb4
+		0x4b, 0xc4, 0xfc, 0xb4,
+	}
+
+	packets, _, _, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got, want := packets, []Packet{&PictureLossIndication{MediaSSRC: 0x4bc4fcb4}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unmarshal: got %#v, want %#v", got, want)
+	}
+
+	var pli PictureLossIndication
+	if err := pli.Unmarshal([]byte{0x81, 0xce, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00}); !errors.Is(err, errPacketTooShort) {
+		t.Fatalf("Unmarshal without media SSRC: err = %v, want %v", err, errPacketTooShort)
+	}
+}
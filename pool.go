@@ -0,0 +1,87 @@
+package rtcp
+
+import "sync"
+
+// The Get* and Put* functions below hand out packets from a sync.Pool, to
+// cut down on allocations in servers that parse many datagrams per second.
+//
+// Ownership works as follows:
+//
+// - A packet returned by a Get* function belongs to the caller until it is
+//   handed back with the matching Put* function. It is always empty, but
+//   its slices may have spare capacity left over from a previous use.
+//
+// - After calling Put*, the caller must not use the packet, or any slice
+//   read from it, again. The packet will be Reset and given to another
+//   caller.
+//
+// - Unmarshal may leave fields such as ProfileExtensions pointing into the
+//   buffer the packet was decoded from. Those bytes are never owned by the
+//   pool; the caller must keep the buffer alive and unmodified for as long
+//   as the packet is in use.
+//
+// The pools are safe for concurrent use.
+
+//nolint:gochecknoglobals
+var (
+	senderReportPool       = sync.Pool{New: func() interface{} { return new(SenderReport) }}
+	receiverReportPool     = sync.Pool{New: func() interface{} { return new(ReceiverReport) }}
+	sourceDescriptionPool  = sync.Pool{New: func() interface{} { return new(SourceDescription) }}
+	transportLayerNackPool = sync.Pool{New: func() interface{} { return new(TransportLayerNack) }}
+	transportLayerCCPool   = sync.Pool{New: func() interface{} { return new(TransportLayerCC) }}
+)
+
+// GetSenderReport returns an empty SenderReport from the pool.
+func GetSenderReport() *SenderReport {
+	return senderReportPool.Get().(*SenderReport)
+}
+
+// PutSenderReport resets p and returns it to the pool.
+func PutSenderReport(p *SenderReport) {
+	p.Reset()
+	senderReportPool.Put(p)
+}
+
+// GetReceiverReport returns an empty ReceiverReport from the pool.
+func GetReceiverReport() *ReceiverReport {
+	return receiverReportPool.Get().(*ReceiverReport)
+}
+
+// PutReceiverReport resets p and returns it to the pool.
+func PutReceiverReport(p *ReceiverReport) {
+	p.Reset()
+	receiverReportPool.Put(p)
+}
+
+// GetSourceDescription returns an empty SourceDescription from the pool.
+func GetSourceDescription() *SourceDescription {
+	return sourceDescriptionPool.Get().(*SourceDescription)
+}
+
+// PutSourceDescription resets p and returns it to the pool.
+func PutSourceDescription(p *SourceDescription) {
+	p.Reset()
+	sourceDescriptionPool.Put(p)
+}
+
+// GetTransportLayerNack returns an empty TransportLayerNack from the pool.
+func GetTransportLayerNack() *TransportLayerNack {
+	return transportLayerNackPool.Get().(*TransportLayerNack)
+}
+
+// PutTransportLayerNack resets p and returns it to the pool.
+func PutTransportLayerNack(p *TransportLayerNack) {
+	p.Reset()
+	transportLayerNackPool.Put(p)
+}
+
+// GetTransportLayerCC returns an empty TransportLayerCC from the pool.
+func GetTransportLayerCC() *TransportLayerCC {
+	return transportLayerCCPool.Get().(*TransportLayerCC)
+}
+
+// PutTransportLayerCC resets p and returns it to the pool.
+func PutTransportLayerCC(p *TransportLayerCC) {
+	p.Reset()
+	transportLayerCCPool.Put(p)
+}
@@ -0,0 +1,79 @@
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPacketPool(t *testing.T) {
+	sr := GetSenderReport()
+	assert.Equal(t, uint32(0), sr.SSRC)
+	assert.Empty(t, sr.Reports)
+
+	data, err := benchmarkPackets()[0].Packet.Marshal()
+	assert.NoError(t, err)
+	assert.NoError(t, sr.Unmarshal(data))
+	assert.Len(t, sr.Reports, 1)
+
+	PutSenderReport(sr)
+	assert.Equal(t, uint32(0), sr.SSRC)
+	assert.Empty(t, sr.Reports)
+	assert.Nil(t, sr.ProfileExtensions)
+
+	// A reset packet decodes exactly like a fresh one.
+	reused := GetSenderReport()
+	assert.NoError(t, reused.Unmarshal(data))
+	var fresh SenderReport
+	assert.NoError(t, fresh.Unmarshal(data))
+	assert.Equal(t, fresh, *reused)
+	PutSenderReport(reused)
+
+	rr := GetReceiverReport()
+	rr.Reports = append(rr.Reports, ReceptionReport{SSRC: 1})
+	PutReceiverReport(rr)
+	assert.Empty(t, rr.Reports)
+
+	sdes := GetSourceDescription()
+	sdes.Chunks = append(sdes.Chunks, SourceDescriptionChunk{Source: 1})
+	PutSourceDescription(sdes)
+	assert.Empty(t, sdes.Chunks)
+
+	nack := GetTransportLayerNack()
+	nack.Nacks = append(nack.Nacks, NackPair{PacketID: 1})
+	PutTransportLayerNack(nack)
+	assert.Empty(t, nack.Nacks)
+
+	cc := GetTransportLayerCC()
+	cc.RecvDeltas = append(cc.RecvDeltas, &RecvDelta{})
+	PutTransportLayerCC(cc)
+	assert.Empty(t, cc.RecvDeltas)
+}
+
+func BenchmarkSenderReportUnmarshal(b *testing.B) {
+	data, err := benchmarkPackets()[0].Packet.Marshal()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("New", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sr := new(SenderReport)
+			if err := sr.Unmarshal(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Pool", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sr := GetSenderReport()
+			if err := sr.Unmarshal(data); err != nil {
+				b.Fatal(err)
+			}
+			PutSenderReport(sr)
+		}
+	})
+}
@@ -80,6 +80,11 @@ func (p *RapidResynchronizationRequest) Header() Header {
 	}
 }
 
+// Reset clears the RapidResynchronizationRequest so it can be reused.
+func (p *RapidResynchronizationRequest) Reset() {
+	*p = RapidResynchronizationRequest{}
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (p *RapidResynchronizationRequest) DestinationSSRC() []uint32 {
 	return []uint32{p.MediaSSRC}
@@ -31,6 +31,11 @@ func (r RawPacket) Header() Header {
 	return h
 }
 
+// Reset clears the RawPacket so it can be reused.
+func (r *RawPacket) Reset() {
+	*r = (*r)[:0]
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (r *RawPacket) DestinationSSRC() []uint32 {
 	return []uint32{}
@@ -40,3 +45,43 @@ func (r RawPacket) String() string {
 	out := fmt.Sprintf("RawPacket: %v", ([]byte)(r))
 	return out
 }
+
+// TruncatedPacket holds the remainder of a datagram whose final packet
+// declares a length longer than the bytes that are actually present. It is
+// only returned by UnmarshalOptions.Unmarshal when AllowTruncated is set.
+type TruncatedPacket []byte
+
+// Marshal encodes the packet in binary.
+func (r TruncatedPacket) Marshal() ([]byte, error) {
+	return r, nil
+}
+
+// Unmarshal decodes the packet from binary.
+func (r *TruncatedPacket) Unmarshal(b []byte) error {
+	if len(b) < (headerLength) {
+		return errPacketTooShort
+	}
+	*r = b
+
+	var h Header
+	return h.Unmarshal(b)
+}
+
+// Header returns the Header associated with this packet.
+func (r TruncatedPacket) Header() Header {
+	var h Header
+	if err := h.Unmarshal(r); err != nil {
+		return Header{}
+	}
+	return h
+}
+
+// DestinationSSRC returns an array of SSRC values that this packet refers to.
+func (r *TruncatedPacket) DestinationSSRC() []uint32 {
+	return []uint32{}
+}
+
+func (r TruncatedPacket) String() string {
+	h := r.Header()
+	return fmt.Sprintf("TruncatedPacket: %s declared %d bytes, have %d", h.Type, int(h.Length+1)*4, len(r))
+}
@@ -6,7 +6,10 @@ import (
 	"testing"
 )
 
-var _ Packet = (*RawPacket)(nil) // assert is a Packet
+var (
+	_ Packet = (*RawPacket)(nil)       // assert is a Packet
+	_ Packet = (*TruncatedPacket)(nil) // assert is a Packet
+)
 
 func TestRawPacketRoundTrip(t *testing.T) {
 	for _, test := range []struct {
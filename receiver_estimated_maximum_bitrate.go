@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"sort"
 )
 
 // ReceiverEstimatedMaximumBitrate contains the receiver's estimated maximum bitrate.
@@ -16,7 +17,9 @@ type ReceiverEstimatedMaximumBitrate struct {
 	// Estimated maximum bitrate
 	Bitrate float32
 
-	// SSRC entries which this packet applies to
+	// SSRC entries which this packet applies to. An empty list, sent with
+	// Num SSRC set to 0, applies the estimate to the whole session; it is
+	// decoded as nil.
 	SSRCs []uint32
 }
 
@@ -204,7 +207,7 @@ func (p *ReceiverEstimatedMaximumBitrate) Unmarshal(buf []byte) (err error) {
 	}
 
 	// REMB rules all around me
-	if !bytes.Equal(buf[12:16], []byte{'R', 'E', 'M', 'B'}) {
+	if !hasREMBIdentifier(buf) {
 		return errMissingREMBidentifier
 	}
 
@@ -248,6 +251,16 @@ func (p *ReceiverEstimatedMaximumBitrate) Unmarshal(buf []byte) (err error) {
 	return nil
 }
 
+// hasREMBIdentifier reports whether the Application Layer Feedback packet in
+// buf carries the 'R' 'E' 'M' 'B' unique identifier.
+func hasREMBIdentifier(buf []byte) bool {
+	const identifierOffset = 12
+	if len(buf) < identifierOffset+4 {
+		return false
+	}
+	return bytes.Equal(buf[identifierOffset:identifierOffset+4], []byte{'R', 'E', 'M', 'B'})
+}
+
 // Header returns the Header associated with this packet.
 func (p *ReceiverEstimatedMaximumBitrate) Header() Header {
 	return Header{
@@ -277,7 +290,73 @@ func (p *ReceiverEstimatedMaximumBitrate) String() string {
 	return fmt.Sprintf("ReceiverEstimatedMaximumBitrate %x %.2f %s/s", p.SenderSSRC, bitrate, unit)
 }
 
+// Reset clears the ReceiverEstimatedMaximumBitrate so it can be reused.
+func (p *ReceiverEstimatedMaximumBitrate) Reset() {
+	*p = ReceiverEstimatedMaximumBitrate{}
+}
+
+// Normalize sorts the SSRCs in ascending order. Their order carries no
+// meaning, so two estimates that differ only in it marshal identically
+// once normalized.
+func (p *ReceiverEstimatedMaximumBitrate) Normalize() {
+	sort.Slice(p.SSRCs, func(i, j int) bool { return p.SSRCs[i] < p.SSRCs[j] })
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (p *ReceiverEstimatedMaximumBitrate) DestinationSSRC() []uint32 {
 	return p.SSRCs
 }
+
+// MergeREMB combines REMB packets from several receivers into one, as an
+// SFU does before forwarding an estimate upstream. The merged packet takes
+// the lowest, most conservative, bitrate and the union of the SSRCs, in
+// order of first appearance. Its SenderSSRC is taken from the first packet.
+// MergeREMB returns nil if packets is empty.
+func MergeREMB(packets []*ReceiverEstimatedMaximumBitrate) *ReceiverEstimatedMaximumBitrate {
+	if len(packets) == 0 {
+		return nil
+	}
+
+	merged := &ReceiverEstimatedMaximumBitrate{
+		SenderSSRC: packets[0].SenderSSRC,
+		Bitrate:    packets[0].Bitrate,
+	}
+	seen := make(map[uint32]struct{})
+	for _, p := range packets {
+		if p.Bitrate < merged.Bitrate {
+			merged.Bitrate = p.Bitrate
+		}
+		for _, ssrc := range p.SSRCs {
+			if _, ok := seen[ssrc]; !ok {
+				seen[ssrc] = struct{}{}
+				merged.SSRCs = append(merged.SSRCs, ssrc)
+			}
+		}
+	}
+	return merged
+}
+
+// SelectSimulcastLayers returns the indices of the simulcast layers that fit
+// under the bitrate estimated by remb. layerBitrates are in bits per second,
+// ordered from the lowest layer up. Layers are taken in order while their
+// combined bitrate stays within the estimate, so the result is always a
+// prefix of the layers; it is empty if remb is nil or the lowest layer alone
+// exceeds the estimate.
+func SelectSimulcastLayers(remb *ReceiverEstimatedMaximumBitrate, layerBitrates []uint64) []int {
+	if remb == nil {
+		return nil
+	}
+
+	var (
+		selected []int
+		total    uint64
+	)
+	for i, bitrate := range layerBitrates {
+		total += bitrate
+		if float64(total) > float64(remb.Bitrate) {
+			break
+		}
+		selected = append(selected, i)
+	}
+	return selected
+}
@@ -125,3 +125,96 @@ func TestReceiverEstimatedMaximumBitrateOverflow(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(math.Float32frombits(0x62800000), packet.Bitrate)
 }
+
+func TestReceiverEstimatedMaximumBitrateIdentifier(t *testing.T) {
+	assert := assert.New(t)
+
+	// An Application Layer Feedback packet with identifier 'A' 'B' 'C' 'D'
+	input := []byte{143, 206, 0, 5, 0, 0, 0, 1, 0, 0, 0, 0, 65, 66, 67, 68, 1, 26, 32, 223, 72, 116, 237, 22}
+
+	packet := ReceiverEstimatedMaximumBitrate{}
+	assert.ErrorIs(packet.Unmarshal(input), errMissingREMBidentifier)
+
+	packets, _, _, err := Unmarshal(input)
+	assert.NoError(err)
+	if assert.Len(packets, 1) {
+		raw, ok := packets[0].(*RawPacket)
+		assert.True(ok, "got %T, want *RawPacket", packets[0])
+		assert.Equal(RawPacket(input), *raw)
+	}
+}
+
+func TestMergeREMB(t *testing.T) {
+	assert := assert.New(t)
+
+	merged := MergeREMB([]*ReceiverEstimatedMaximumBitrate{
+		{SenderSSRC: 1, Bitrate: 2000000, SSRCs: []uint32{0x10, 0x20}},
+		{SenderSSRC: 2, Bitrate: 500000, SSRCs: []uint32{0x20, 0x30}},
+		{SenderSSRC: 3, Bitrate: 1000000, SSRCs: []uint32{0x40}},
+	})
+	assert.Equal(&ReceiverEstimatedMaximumBitrate{
+		SenderSSRC: 1,
+		Bitrate:    500000,
+		SSRCs:      []uint32{0x10, 0x20, 0x30, 0x40},
+	}, merged)
+
+	assert.Nil(MergeREMB(nil))
+}
+
+func TestReceiverEstimatedMaximumBitrateSessionWide(t *testing.T) {
+	assert := assert.New(t)
+
+	// Num SSRC = 0: the estimate applies to the whole session.
+	expected := []byte{143, 206, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0, 82, 69, 77, 66, 0, 26, 32, 223}
+
+	for _, ssrcs := range [][]uint32{nil, {}} {
+		input := ReceiverEstimatedMaximumBitrate{
+			SenderSSRC: 1,
+			Bitrate:    8927168,
+			SSRCs:      ssrcs,
+		}
+
+		output, err := input.Marshal()
+		assert.NoError(err)
+		assert.Equal(expected, output)
+	}
+
+	packets, _, _, err := Unmarshal(expected)
+	assert.NoError(err)
+	assert.Equal([]Packet{&ReceiverEstimatedMaximumBitrate{
+		SenderSSRC: 1,
+		Bitrate:    8927168,
+	}}, packets)
+	assert.Empty(packets[0].DestinationSSRC())
+}
+
+func TestSelectSimulcastLayers(t *testing.T) {
+	assert := assert.New(t)
+
+	layers := []uint64{150000, 500000, 1500000}
+	remb := &ReceiverEstimatedMaximumBitrate{SenderSSRC: 1, Bitrate: 1000000}
+	assert.Equal([]int{0, 1}, SelectSimulcastLayers(remb, layers))
+
+	remb.Bitrate = 2150000
+	assert.Equal([]int{0, 1, 2}, SelectSimulcastLayers(remb, layers))
+
+	remb.Bitrate = 100000
+	assert.Empty(SelectSimulcastLayers(remb, layers))
+
+	assert.Empty(SelectSimulcastLayers(nil, layers))
+}
+
+func TestReceiverEstimatedMaximumBitrateNormalize(t *testing.T) {
+	assert := assert.New(t)
+
+	a := &ReceiverEstimatedMaximumBitrate{SenderSSRC: 1, Bitrate: 8927168, SSRCs: []uint32{3, 1, 2}}
+	b := &ReceiverEstimatedMaximumBitrate{SenderSSRC: 1, Bitrate: 8927168, SSRCs: []uint32{2, 3, 1}}
+	assert.True(SemanticEqual(a, b))
+	assert.Equal([]uint32{3, 1, 2}, a.SSRCs, "SemanticEqual must not modify its arguments")
+
+	a.Normalize()
+	assert.Equal([]uint32{1, 2, 3}, a.SSRCs)
+
+	c := &ReceiverEstimatedMaximumBitrate{SenderSSRC: 1, Bitrate: 8927168, SSRCs: []uint32{2, 3, 4}}
+	assert.False(SemanticEqual(a, c))
+}
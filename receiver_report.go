@@ -3,6 +3,7 @@ package rtcp
 import (
 	"encoding/binary"
 	"fmt"
+	"time"
 )
 
 // A ReceiverReport (RR) packet provides reception quality feedback for an RTP stream
@@ -17,6 +18,15 @@ type ReceiverReport struct {
 	// Extension contains additional, payload-specific information that needs to
 	// be reported regularly about the receiver.
 	ProfileExtensions []byte
+	// Arrival is the time the packet was received, as set by UnmarshalAt.
+	// It is not part of the wire format and is ignored by Marshal.
+	Arrival time.Time `fmt:"-"`
+
+	// paddingLength is the number of padding octets, count included, that
+	// ended the packet when it was decoded. They are kept out of
+	// ProfileExtensions and written back by Marshal, so that a decoded
+	// packet is reproduced byte for byte.
+	paddingLength int
 }
 
 const (
@@ -55,6 +65,10 @@ func (r ReceiverReport) Marshal() ([]byte, error) {
 	 *        +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
 
+	if err := checkPacketLength(r.len() + r.extensionsLength()); err != nil {
+		return nil, err
+	}
+
 	rawPacket := make([]byte, r.len())
 	packetBody := rawPacket[headerLength:]
 
@@ -78,8 +92,12 @@ func (r ReceiverReport) Marshal() ([]byte, error) {
 
 	// if the length of the profile extensions isn't devisible
 	// by 4, we need to pad the end.
-	for (len(pe) & 0x3) != 0 {
-		pe = append(pe, 0)
+	if padding := r.padding(); padding != 0 {
+		pe = appendPadding(pe, padding)
+	} else {
+		for (len(pe) & 0x3) != 0 {
+			pe = append(pe, 0)
+		}
 	}
 
 	rawPacket = append(rawPacket, pe...)
@@ -136,6 +154,15 @@ func (r *ReceiverReport) Unmarshal(rawPacket []byte) error {
 		return errWrongType
 	}
 
+	if h.Padding {
+		padding, err := trailingPadding(rawPacket, rrReportOffset)
+		if err != nil {
+			return err
+		}
+		r.paddingLength = padding
+		rawPacket = rawPacket[:len(rawPacket)-padding]
+	}
+
 	r.SSRC = binary.BigEndian.Uint32(rawPacket[rrSSRCOffset:])
 
 	for i := rrReportOffset; i < len(rawPacket) && len(r.Reports) < int(h.Count); i += receptionReportLength {
@@ -154,6 +181,43 @@ func (r *ReceiverReport) Unmarshal(rawPacket []byte) error {
 	return nil
 }
 
+// ReceptionReports returns the reception report blocks carried by this packet.
+func (r *ReceiverReport) ReceptionReports() []ReceptionReport {
+	return r.Reports
+}
+
+// IsKeepalive reports whether r carries no reception report blocks. Such an
+// RR tells the peer the receiver is alive without reporting on any source,
+// as sent by receivers that are not currently getting media.
+func (r *ReceiverReport) IsKeepalive() bool {
+	return len(r.Reports) == 0
+}
+
+// Validate reports likely mistakes in a ReceiverReport that Marshal accepts,
+// such as a zero reporter SSRC left by an uninitialized field.
+func (r *ReceiverReport) Validate() error {
+	if r.SSRC == 0 {
+		return errZeroReporterSSRC
+	}
+	return nil
+}
+
+// padding returns the number of padding octets Marshal writes after the
+// ProfileExtensions.
+func (r *ReceiverReport) padding() int {
+	return reusePadding(len(r.ProfileExtensions), r.paddingLength)
+}
+
+// extensionsLength returns the number of octets Marshal writes after the
+// report blocks: the ProfileExtensions, then either padding or the zeros
+// that align them.
+func (r *ReceiverReport) extensionsLength() int {
+	if padding := r.padding(); padding != 0 {
+		return len(r.ProfileExtensions) + padding
+	}
+	return len(r.ProfileExtensions) + getPadding(len(r.ProfileExtensions))
+}
+
 func (r *ReceiverReport) len() int {
 	repsLength := 0
 	for _, rep := range r.Reports {
@@ -165,12 +229,19 @@ func (r *ReceiverReport) len() int {
 // Header returns the Header associated with this packet.
 func (r *ReceiverReport) Header() Header {
 	return Header{
-		Count:  uint8(len(r.Reports)),
-		Type:   TypeReceiverReport,
-		Length: uint16((r.len()/4)-1) + uint16(getPadding(len(r.ProfileExtensions))),
+		Padding: r.padding() != 0,
+		Count:   uint8(len(r.Reports)),
+		Type:    TypeReceiverReport,
+		Length:  uint16((r.len()+r.extensionsLength())/4 - 1),
 	}
 }
 
+// Reset clears the ReceiverReport so it can be reused, keeping the capacity
+// of its Reports slice.
+func (r *ReceiverReport) Reset() {
+	*r = ReceiverReport{Reports: r.Reports[:0]}
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (r *ReceiverReport) DestinationSSRC() []uint32 {
 	out := make([]uint32, len(r.Reports))
@@ -239,6 +239,26 @@ func TestReceiverReportRoundTrip(t *testing.T) {
 			},
 			WantError: errInvalidTotalLost,
 		},
+		{
+			Name: "totallost just past 24 bits",
+			Report: ReceiverReport{
+				SSRC: 1,
+				Reports: []ReceptionReport{{
+					TotalLost: 1 << 24,
+				}},
+			},
+			WantError: errInvalidTotalLost,
+		},
+		{
+			Name: "totallost max",
+			Report: ReceiverReport{
+				SSRC: 1,
+				Reports: []ReceptionReport{{
+					TotalLost: 1<<24 - 1,
+				}},
+				ProfileExtensions: []byte{},
+			},
+		},
 		{
 			Name: "count overflow",
 			Report: ReceiverReport{
@@ -266,3 +286,115 @@ func TestReceiverReportRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestReceiverReportValidate(t *testing.T) {
+	if err := (&ReceiverReport{SSRC: 0x902f9e2e}).Validate(); err != nil {
+		t.Errorf("Validate() err = %v, want nil", err)
+	}
+	if got, want := (&ReceiverReport{}).Validate(), errZeroReporterSSRC; !errors.Is(got, want) {
+		t.Errorf("Validate() err = %v, want %v", got, want)
+	}
+}
+
+func TestReceiverReportIsKeepalive(t *testing.T) {
+	if !(&ReceiverReport{SSRC: 0x902f9e2e}).IsKeepalive() {
+		t.Errorf("IsKeepalive() = false for RR without reports, want true")
+	}
+	rr := ReceiverReport{
+		SSRC:    0x902f9e2e,
+		Reports: []ReceptionReport{{SSRC: 0xbc5e9a40}},
+	}
+	if rr.IsKeepalive() {
+		t.Errorf("IsKeepalive() = true for RR with reports, want false")
+	}
+}
+
+func TestReceiverReportUnmarshalExtraBlocks(t *testing.T) {
+	// count=1, but the body holds two report blocks
+	data := []byte{
+		// v=2, p=0, count=1, RR, len=13
+		0x81, 0xc9, 0x0, 0xd,
+		// ssrc=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// block 1: ssrc=0xbc5e9a40
+		0xbc, 0x5e, 0x9a, 0x40,
+		0x0, 0x0, 0x0, 0x0,
+		0x0, 0x0, 0x46, 0xe1,
+		0x0, 0x0, 0x1, 0x11,
+		0x9, 0xf3, 0x64, 0x32,
+		0x0, 0x2, 0x4a, 0x79,
+		// phantom block 2: ssrc=0x11223344
+		0x11, 0x22, 0x33, 0x44,
+		0x0, 0x0, 0x0, 0x0,
+		0x0, 0x0, 0x0, 0x0,
+		0x0, 0x0, 0x0, 0x0,
+		0x0, 0x0, 0x0, 0x0,
+		0x0, 0x0, 0x0, 0x0,
+	}
+
+	packets, _, _, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	rr, ok := packets[0].(*ReceiverReport)
+	if !ok {
+		t.Fatalf("Unmarshal: got %T, want *ReceiverReport", packets[0])
+	}
+	if len(rr.Reports) != 1 || rr.Reports[0].SSRC != 0xbc5e9a40 {
+		t.Fatalf("Unmarshal: got reports %#v, want only the declared block", rr.Reports)
+	}
+	if got, want := rr.ProfileExtensions, data[32:]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unmarshal: got profile extensions %#v, want %#v", got, want)
+	}
+}
+
+func TestReceiverReportRemarshalByteStable(t *testing.T) {
+	data := []byte{
+		// v=2, p=1, count=2, RR, len=15
+		0xa2, 0xc9, 0x00, 0x0f,
+		// ssrc=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// ssrc=0xbc5e9a40, then ssrc=0x12345678
+		0xbc, 0x5e, 0x9a, 0x40,
+		0x01, 0x00, 0x00, 0x02,
+		0x00, 0x00, 0x46, 0xe1,
+		0x00, 0x00, 0x01, 0x11,
+		0x09, 0xf3, 0x64, 0x32,
+		0x00, 0x02, 0x4a, 0x79,
+		0x12, 0x34, 0x56, 0x78,
+		0x03, 0x00, 0x00, 0x04,
+		0x00, 0x01, 0x00, 0x05,
+		0x00, 0x00, 0x00, 0x06,
+		0x00, 0x00, 0x00, 0x07,
+		0x00, 0x00, 0x00, 0x08,
+		// profile-specific extension data, then 4 bytes of padding
+		0x54, 0x45, 0x53, 0x54,
+		0x00, 0x00, 0x00, 0x04,
+	}
+
+	var r ReceiverReport
+	if err := r.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal err = %v", err)
+	}
+	if got, want := r.Reports[0].SSRC, uint32(0xbc5e9a40); got != want {
+		t.Fatalf("first report SSRC = %x, want %x", got, want)
+	}
+
+	remarshaled, err := r.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+	if !reflect.DeepEqual(remarshaled, data) {
+		t.Errorf("Marshal(Unmarshal(x)) = %x, want %x", remarshaled, data)
+	}
+
+	// Dropping the extension keeps the padding out of the report blocks.
+	r.ProfileExtensions = nil
+	remarshaled, err = r.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+	if want := append(append([]byte{0xa2, 0xc9, 0x00, 0x0e}, data[4:56]...), 0x00, 0x00, 0x00, 0x04); !reflect.DeepEqual(remarshaled, want) {
+		t.Errorf("Marshal without extension = %x, want %x", remarshaled, want)
+	}
+}
@@ -71,13 +71,10 @@ func (r ReceptionReport) Marshal() ([]byte, error) {
 	rawPacket[fractionLostOffset] = r.FractionLost
 
 	// pack TotalLost into 24 bits
-	if r.TotalLost >= (1 << 25) {
+	if r.TotalLost >= (1 << 24) {
 		return nil, errInvalidTotalLost
 	}
-	tlBytes := rawPacket[totalLostOffset:]
-	tlBytes[0] = byte(r.TotalLost >> 16)
-	tlBytes[1] = byte(r.TotalLost >> 8)
-	tlBytes[2] = byte(r.TotalLost)
+	put24(rawPacket[totalLostOffset:], r.TotalLost)
 
 	binary.BigEndian.PutUint32(rawPacket[lastSeqOffset:], r.LastSequenceNumber)
 	binary.BigEndian.PutUint32(rawPacket[jitterOffset:], r.Jitter)
@@ -114,8 +111,7 @@ func (r *ReceptionReport) Unmarshal(rawPacket []byte) error {
 	r.SSRC = binary.BigEndian.Uint32(rawPacket)
 	r.FractionLost = rawPacket[fractionLostOffset]
 
-	tlBytes := rawPacket[totalLostOffset:]
-	r.TotalLost = uint32(tlBytes[2]) | uint32(tlBytes[1])<<8 | uint32(tlBytes[0])<<16
+	r.TotalLost = get24(rawPacket[totalLostOffset:])
 
 	r.LastSequenceNumber = binary.BigEndian.Uint32(rawPacket[lastSeqOffset:])
 	r.Jitter = binary.BigEndian.Uint32(rawPacket[jitterOffset:])
@@ -125,6 +121,36 @@ func (r *ReceptionReport) Unmarshal(rawPacket []byte) error {
 	return nil
 }
 
+// SetLastSR sets LastSenderReport from the NTP timestamp of sr, the most
+// recent SenderReport received from the source this block reports on.
+func (r *ReceptionReport) SetLastSR(sr *SenderReport) {
+	r.LastSenderReport = uint32(sr.NTPTime >> 16)
+}
+
+// LastSR returns the middle 32 bits of the NTP timestamp of the last
+// SenderReport received from the source, as set by SetLastSR.
+func (r *ReceptionReport) LastSR() uint32 {
+	return r.LastSenderReport
+}
+
+// Cycles returns the count of sequence number cycles, the high 16 bits of
+// LastSequenceNumber.
+func (r *ReceptionReport) Cycles() uint16 {
+	return uint16(r.LastSequenceNumber >> 16)
+}
+
+// HighestSequence returns the highest sequence number received, the low 16
+// bits of LastSequenceNumber.
+func (r *ReceptionReport) HighestSequence() uint16 {
+	return uint16(r.LastSequenceNumber)
+}
+
+// SetExtendedHighest sets LastSequenceNumber from the count of sequence
+// number cycles and the highest sequence number received.
+func (r *ReceptionReport) SetExtendedHighest(cycles, highest uint16) {
+	r.LastSequenceNumber = uint32(cycles)<<16 | uint32(highest)
+}
+
 func (r *ReceptionReport) len() int {
 	return receptionReportLength
 }
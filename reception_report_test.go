@@ -0,0 +1,44 @@
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceptionReportSetLastSR(t *testing.T) {
+	sr := &SenderReport{
+		SSRC: 0x902f9e2e,
+		// seconds=0xda8bd1fc, fraction=0xdddda05a
+		NTPTime: 0xda8bd1fcdddda05a,
+	}
+
+	var rr ReceptionReport
+	rr.SetLastSR(sr)
+
+	// low 16 bits of the seconds, high 16 bits of the fraction
+	assert.Equal(t, uint32(0xd1fcdddd), rr.LastSR())
+	assert.Equal(t, uint32(0xd1fcdddd), rr.LastSenderReport)
+
+	data, err := rr.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xd1, 0xfc, 0xdd, 0xdd}, data[lastSROffset:lastSROffset+4])
+}
+
+func TestReceptionReportExtendedHighest(t *testing.T) {
+	var rr ReceptionReport
+	rr.SetExtendedHighest(3, 0xfff0)
+
+	assert.Equal(t, uint32(0x0003fff0), rr.LastSequenceNumber)
+	assert.Equal(t, uint16(3), rr.Cycles())
+	assert.Equal(t, uint16(0xfff0), rr.HighestSequence())
+
+	data, err := rr.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x03, 0xff, 0xf0}, data[lastSeqOffset:lastSeqOffset+4])
+
+	var decoded ReceptionReport
+	assert.NoError(t, decoded.Unmarshal(data))
+	assert.Equal(t, uint16(3), decoded.Cycles())
+	assert.Equal(t, uint16(0xfff0), decoded.HighestSequence())
+}
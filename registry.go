@@ -0,0 +1,49 @@
+package rtcp
+
+import "sync"
+
+type packetTypeKey struct {
+	typ PacketType
+	fmt uint8
+}
+
+//nolint:gochecknoglobals
+var (
+	registryMu sync.RWMutex
+	registry   = map[packetTypeKey]func() Packet{}
+)
+
+// RegisterPacketType makes Unmarshal decode packets of type t whose header
+// count field equals fmt using a Packet returned by factory, instead of
+// returning them as a RawPacket. For feedback packets fmt is the feedback
+// message type; for other packet types it is the 5-bit count or subtype
+// field. Packets this package already decodes are not affected.
+//
+// RegisterPacketType is safe to call concurrently with Unmarshal, but it
+// is intended to be called from an init function, before any packets are
+// parsed. A nil factory removes the registration.
+func RegisterPacketType(t PacketType, fmt uint8, factory func() Packet) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	key := packetTypeKey{typ: t, fmt: fmt}
+	if factory == nil {
+		delete(registry, key)
+		return
+	}
+	registry[key] = factory
+}
+
+// newUnknownPacket returns the Packet used to decode a packet with header
+// h that has no built-in decoder: one from a registered factory if there
+// is one, otherwise a RawPacket.
+func newUnknownPacket(h Header) Packet {
+	registryMu.RLock()
+	factory, ok := registry[packetTypeKey{typ: h.Type, fmt: h.Count}]
+	registryMu.RUnlock()
+
+	if ok {
+		return factory()
+	}
+	return new(RawPacket)
+}
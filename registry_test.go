@@ -0,0 +1,73 @@
+package rtcp
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// vendorFeedback is a made up payload-specific feedback message, FMT 9,
+// carrying a single 32-bit value after the SSRCs.
+type vendorFeedback struct {
+	SenderSSRC uint32
+	MediaSSRC  uint32
+	Value      uint32
+}
+
+func (p *vendorFeedback) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, 16)
+	hData, err := Header{Count: 9, Type: TypePayloadSpecificFeedback, Length: 3}.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	copy(rawPacket, hData)
+	binary.BigEndian.PutUint32(rawPacket[4:], p.SenderSSRC)
+	binary.BigEndian.PutUint32(rawPacket[8:], p.MediaSSRC)
+	binary.BigEndian.PutUint32(rawPacket[12:], p.Value)
+	return rawPacket, nil
+}
+
+func (p *vendorFeedback) Unmarshal(rawPacket []byte) error {
+	if len(rawPacket) < 16 {
+		return errPacketTooShort
+	}
+	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[4:])
+	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[8:])
+	p.Value = binary.BigEndian.Uint32(rawPacket[12:])
+	return nil
+}
+
+func (p *vendorFeedback) DestinationSSRC() []uint32 {
+	return []uint32{p.MediaSSRC}
+}
+
+func TestRegisterPacketType(t *testing.T) {
+	data, err := Marshal([]Packet{
+		&vendorFeedback{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x4bc4fcb4, Value: 42},
+		&PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x4bc4fcb4},
+	})
+	assert.NoError(t, err)
+
+	packets, _, _, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.IsType(t, &RawPacket{}, packets[0])
+
+	RegisterPacketType(TypePayloadSpecificFeedback, 9, func() Packet { return new(vendorFeedback) })
+	defer RegisterPacketType(TypePayloadSpecificFeedback, 9, nil)
+
+	packets, _, _, err = Unmarshal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{
+		&vendorFeedback{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x4bc4fcb4, Value: 42},
+		&PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x4bc4fcb4},
+	}, packets)
+
+	// Built-in decoders take precedence
+	RegisterPacketType(TypePayloadSpecificFeedback, FormatPLI, func() Packet { return new(vendorFeedback) })
+	defer RegisterPacketType(TypePayloadSpecificFeedback, FormatPLI, nil)
+
+	packets, _, _, err = Unmarshal(data)
+	assert.NoError(t, err)
+	assert.IsType(t, &PictureLossIndication{}, packets[1])
+}
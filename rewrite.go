@@ -0,0 +1,140 @@
+package rtcp
+
+import "encoding/binary"
+
+// RewriteSSRC replaces, in place, every SSRC in p that is a key of mapping
+// with the corresponding value. Reporter and sender SSRCs, media SSRCs,
+// report block SSRCs, SDES chunk sources, BYE sources and feedback
+// targets are all rewritten; SSRCs that are not in mapping are left
+// alone. For an undecoded feedback RawPacket the sender and media SSRCs
+// of the common header are rewritten; any other packet type returns
+// errRewriteUnsupported. A CompoundPacket is rewritten packet by packet
+// and stops at the first packet that cannot be rewritten.
+func RewriteSSRC(p Packet, mapping map[uint32]uint32) error { //nolint:gocyclo
+	remap := func(ssrc *uint32) {
+		if to, ok := mapping[*ssrc]; ok {
+			*ssrc = to
+		}
+	}
+
+	switch p := p.(type) {
+	case *SenderReport:
+		remap(&p.SSRC)
+		for i := range p.Reports {
+			remap(&p.Reports[i].SSRC)
+		}
+	case *ReceiverReport:
+		remap(&p.SSRC)
+		for i := range p.Reports {
+			remap(&p.Reports[i].SSRC)
+		}
+	case *SourceDescription:
+		for i := range p.Chunks {
+			remap(&p.Chunks[i].Source)
+		}
+	case *Goodbye:
+		for i := range p.Sources {
+			remap(&p.Sources[i])
+		}
+	case *PictureLossIndication:
+		remap(&p.SenderSSRC)
+		remap(&p.MediaSSRC)
+	case *SliceLossIndication:
+		remap(&p.SenderSSRC)
+		remap(&p.MediaSSRC)
+	case *RapidResynchronizationRequest:
+		remap(&p.SenderSSRC)
+		remap(&p.MediaSSRC)
+	case *ECNFeedback:
+		remap(&p.SenderSSRC)
+		remap(&p.MediaSSRC)
+	case *TransportLayerNack:
+		remap(&p.SenderSSRC)
+		remap(&p.MediaSSRC)
+	case *TransportLayerCC:
+		remap(&p.SenderSSRC)
+		remap(&p.MediaSSRC)
+	case *FullIntraRequest:
+		remap(&p.SenderSSRC)
+		remap(&p.MediaSSRC)
+		for i := range p.FIR {
+			remap(&p.FIR[i].SSRC)
+		}
+	case *ReceiverEstimatedMaximumBitrate:
+		remap(&p.SenderSSRC)
+		for i := range p.SSRCs {
+			remap(&p.SSRCs[i])
+		}
+	case *CCFeedbackReport:
+		remap(&p.SenderSSRC)
+		for i := range p.ReportBlocks {
+			remap(&p.ReportBlocks[i].MediaSSRC)
+		}
+	case *ExtendedReport:
+		remap(&p.SenderSSRC)
+		for _, block := range p.Reports {
+			rewriteReportBlockSSRC(block, remap)
+		}
+	case *CompoundPacket:
+		for _, packet := range *p {
+			if err := RewriteSSRC(packet, mapping); err != nil {
+				return err
+			}
+		}
+	case *RawPacket:
+		return rewriteRawFeedbackSSRC(*p, remap)
+	default:
+		return errRewriteUnsupported
+	}
+	return nil
+}
+
+// rewriteReportBlockSSRC rewrites the source SSRCs of an XR report block.
+func rewriteReportBlockSSRC(block ReportBlock, remap func(*uint32)) {
+	switch b := block.(type) {
+	case *LossRLEReportBlock:
+		remap(&b.SSRC)
+	case *DuplicateRLEReportBlock:
+		remap(&b.SSRC)
+	case *PacketReceiptTimesReportBlock:
+		remap(&b.SSRC)
+	case *DLRRReportBlock:
+		for i := range b.Reports {
+			remap(&b.Reports[i].SSRC)
+		}
+	case *StatisticsSummaryReportBlock:
+		remap(&b.SSRC)
+	case *VoIPMetricsReportBlock:
+		remap(&b.SSRC)
+	}
+}
+
+// rewriteRawFeedbackSSRC rewrites the sender and media SSRCs in the
+// common header of an undecoded feedback packet.
+func rewriteRawFeedbackSSRC(rawPacket []byte, remap func(*uint32)) error {
+	if rawFeedbackTarget(rawPacket) == nil {
+		return errRewriteUnsupported
+	}
+	for _, offset := range []int{headerLength, headerLength + ssrcLength} {
+		ssrc := binary.BigEndian.Uint32(rawPacket[offset:])
+		remap(&ssrc)
+		binary.BigEndian.PutUint32(rawPacket[offset:], ssrc)
+	}
+	return nil
+}
+
+// SameLength reports whether p marshals to exactly len(original) octets,
+// so that a relay which decoded original and rewrote its SSRCs with
+// RewriteSSRC can patch the result over the original buffer instead of
+// allocating a new one. Packets with a MarshalSize method are measured
+// without marshaling them; an error is returned if p cannot be marshaled.
+func SameLength(original []byte, p Packet) (bool, error) {
+	if sized, ok := p.(interface{ MarshalSize() int }); ok {
+		return sized.MarshalSize() == len(original), nil
+	}
+	data, err := p.Marshal()
+	if err != nil {
+		return false, err
+	}
+	return len(data) == len(original), nil
+}
@@ -0,0 +1,104 @@
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteSSRC(t *testing.T) {
+	mapping := map[uint32]uint32{
+		0x902f9e2e: 0x11111111,
+		0xbc5e9a40: 0x22222222,
+	}
+
+	sr := &SenderReport{
+		SSRC:    0x902f9e2e,
+		NTPTime: 0xda8bd1fcdddda05a,
+		Reports: []ReceptionReport{
+			{SSRC: 0xbc5e9a40, LastSequenceNumber: 0x46e1},
+			{SSRC: 0x33333333, LastSequenceNumber: 0x46e2},
+		},
+	}
+	assert.NoError(t, RewriteSSRC(sr, mapping))
+	assert.Equal(t, &SenderReport{
+		SSRC:    0x11111111,
+		NTPTime: 0xda8bd1fcdddda05a,
+		Reports: []ReceptionReport{
+			{SSRC: 0x22222222, LastSequenceNumber: 0x46e1},
+			{SSRC: 0x33333333, LastSequenceNumber: 0x46e2},
+		},
+	}, sr)
+
+	nack := &TransportLayerNack{
+		SenderSSRC: 0x902f9e2e,
+		MediaSSRC:  0xbc5e9a40,
+		Nacks:      []NackPair{{PacketID: 100}},
+	}
+	assert.NoError(t, RewriteSSRC(nack, mapping))
+	assert.Equal(t, &TransportLayerNack{
+		SenderSSRC: 0x11111111,
+		MediaSSRC:  0x22222222,
+		Nacks:      []NackPair{{PacketID: 100}},
+	}, nack)
+
+	compound := &CompoundPacket{
+		NewCNAMESourceDescription(0x902f9e2e, "cname"),
+		&Goodbye{Sources: []uint32{0xbc5e9a40}},
+	}
+	assert.NoError(t, RewriteSSRC(compound, mapping))
+	assert.Equal(t, uint32(0x11111111), (*compound)[0].(*SourceDescription).Chunks[0].Source)
+	assert.Equal(t, []uint32{0x22222222}, (*compound)[1].(*Goodbye).Sources)
+
+	raw := RawPacket{
+		0x81, 0xcd, 0x00, 0x02,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0xbc, 0x5e, 0x9a, 0x40,
+	}
+	assert.NoError(t, RewriteSSRC(&raw, mapping))
+	assert.Equal(t, []uint32{0x22222222}, FeedbackTargets(&raw))
+
+	app := RawPacket{0x80, 0xcc, 0x00, 0x01, 0x90, 0x2f, 0x9e, 0x2e}
+	assert.ErrorIs(t, RewriteSSRC(&app, mapping), errRewriteUnsupported)
+}
+
+func TestSameLength(t *testing.T) {
+	mapping := map[uint32]uint32{0x902f9e2e: 0x11111111, 0xbc5e9a40: 0x22222222}
+
+	for _, p := range []Packet{
+		&CompoundPacket{
+			&ReceiverReport{
+				SSRC:    0x902f9e2e,
+				Reports: []ReceptionReport{{SSRC: 0xbc5e9a40, LastSequenceNumber: 0x46e1}},
+			},
+			NewCNAMESourceDescription(0x902f9e2e, "cname"),
+		},
+		&ReceiverEstimatedMaximumBitrate{SenderSSRC: 0x902f9e2e, Bitrate: 8927168, SSRCs: []uint32{0xbc5e9a40}},
+	} {
+		original, err := p.Marshal()
+		assert.NoError(t, err)
+
+		packets, _, _, err := Unmarshal(original)
+		assert.NoError(t, err)
+		decoded := CompoundPacket(packets)
+		assert.NoError(t, RewriteSSRC(&decoded, mapping))
+
+		var rewritten Packet = &decoded
+		if len(decoded) == 1 {
+			rewritten = decoded[0]
+		}
+		same, err := SameLength(original, rewritten)
+		assert.NoError(t, err)
+		assert.True(t, same)
+	}
+
+	// A longer CNAME grows the packet.
+	original, err := NewCNAMESourceDescription(0x902f9e2e, "cname").Marshal()
+	assert.NoError(t, err)
+	same, err := SameLength(original, NewCNAMESourceDescription(0x11111111, "a longer cname"))
+	assert.NoError(t, err)
+	assert.False(t, same)
+
+	_, err = SameLength(original, &ReceiverReport{Reports: make([]ReceptionReport, 32)})
+	assert.Error(t, err)
+}
@@ -81,6 +81,15 @@ type CCFeedbackReport struct {
 	ReportTimestamp uint32
 }
 
+// Reset clears the CCFeedbackReport so it can be reused, keeping the
+// capacity of its ReportBlocks slice.
+func (b *CCFeedbackReport) Reset() {
+	for i := range b.ReportBlocks {
+		b.ReportBlocks[i] = CCFeedbackReportBlock{}
+	}
+	*b = CCFeedbackReport{ReportBlocks: b.ReportBlocks[:0]}
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (b CCFeedbackReport) DestinationSSRC() []uint32 {
 	ssrcs := make([]uint32, len(b.ReportBlocks))
@@ -90,32 +99,48 @@ func (b CCFeedbackReport) DestinationSSRC() []uint32 {
 	return ssrcs
 }
 
+// computeHeader returns the Header describing the current contents of b
+func (b *CCFeedbackReport) computeHeader() Header {
+	return Header{
+		Count:  FormatCCFB,
+		Type:   TypeTransportSpecificFeedback,
+		Length: uint16(b.len()/4 - 1),
+	}
+}
+
 // Len returns the length of the report in bytes
 func (b *CCFeedbackReport) Len() uint16 {
-	n := uint16(0)
+	return uint16(b.len())
+}
+
+func (b *CCFeedbackReport) len() int {
+	n := 0
 	for _, block := range b.ReportBlocks {
-		n += block.Len()
+		n += int(block.Len())
 	}
 	return reportBlockOffset + n + reportTimestampLength
 }
 
 // Marshal encodes the Congestion Control Feedback Report in binary
 func (b CCFeedbackReport) Marshal() ([]byte, error) {
+	if err := checkPacketLength(b.len()); err != nil {
+		return nil, err
+	}
 	header, err := b.Header.Marshal()
 	if err != nil {
 		return nil, err
 	}
-	buf := make([]byte, b.Len())
+	buf := make([]byte, b.len())
 	copy(buf[:headerLength], header)
 	binary.BigEndian.PutUint32(buf[headerLength:], b.SenderSSRC)
-	offset := uint16(reportBlockOffset)
+	offset := reportBlockOffset
 	for _, block := range b.ReportBlocks {
 		b, err := block.marshal()
 		if err != nil {
 			return nil, err
 		}
 		copy(buf[offset:], b)
-		offset += block.Len()
+		offset += int(block.Len())
 	}
 
 	binary.BigEndian.PutUint32(buf[offset:], b.ReportTimestamp)
@@ -207,6 +232,9 @@ func (b *CCFeedbackReportBlock) unmarshal(rawPacket []byte) error {
 	b.MediaSSRC = binary.BigEndian.Uint32(rawPacket[:beginSequenceOffset])
 	b.BeginSequence = binary.BigEndian.Uint16(rawPacket[beginSequenceOffset:numReportsOffset])
 	numReports := binary.BigEndian.Uint16(rawPacket[numReportsOffset:])
+	if numReports > maxMetricBlocks {
+		return errTooManyReports
+	}
 	if len(rawPacket) < int(reportsOffset+numReports*2) {
 		return errIncorrectNumReports
 	}
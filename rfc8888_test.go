@@ -266,6 +266,18 @@ func TestCCFeedbackReportBlockUnmarshalMarshal(t *testing.T) {
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, errIncorrectNumReports)
 	})
+
+	t.Run("tooManyMetricBlocks", func(t *testing.T) {
+		var block CCFeedbackReportBlock
+		data := []byte{
+			0x00, 0x00, 0x00, 0x01, // SSRC
+			0x00, 0x02, 0xFF, 0xFF, // begin_seq, num_reports
+			0x9F, 0xFD, 0x9F, 0xFC, // reports[0], reports[1]
+		}
+		err := block.unmarshal(data)
+		assert.ErrorIs(t, err, errTooManyReports)
+		assert.Nil(t, block.MetricBlocks)
+	})
 }
 
 func TestCCFeedbackReportUnmarshalMarshal(t *testing.T) {
@@ -377,3 +389,28 @@ func TestCCFeedbackReportUnmarshalMarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestCCFeedbackReportMarshalLength(t *testing.T) {
+	report := func(blocks, metrics int) CCFeedbackReport {
+		r := CCFeedbackReport{SenderSSRC: 1}
+		for i := 0; i < blocks; i++ {
+			r.ReportBlocks = append(r.ReportBlocks, CCFeedbackReportBlock{
+				MediaSSRC:    uint32(i),
+				MetricBlocks: make([]CCFeedbackMetricBlock, metrics),
+			})
+		}
+		r.Header = r.computeHeader()
+		return r
+	}
+
+	// More octets than a uint16 holds, but within the header length field
+	data, err := report(5, 16000).Marshal()
+	assert.NoError(t, err)
+	assert.Len(t, data, 160052)
+	var got CCFeedbackReport
+	assert.NoError(t, got.Header.Unmarshal(data))
+	assert.Equal(t, uint16(160052/4-1), got.Header.Length)
+
+	_, err = report(9, maxMetricBlocks).Marshal()
+	assert.ErrorIs(t, err, errPacketTooLong)
+}
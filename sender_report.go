@@ -3,6 +3,7 @@ package rtcp
 import (
 	"encoding/binary"
 	"fmt"
+	"time"
 )
 
 // A SenderReport (SR) packet provides reception quality feedback for an RTP stream
@@ -37,6 +38,15 @@ type SenderReport struct {
 	// ProfileExtensions contains additional, payload-specific information that needs to
 	// be reported regularly about the sender.
 	ProfileExtensions []byte
+	// Arrival is the time the packet was received, as set by UnmarshalAt.
+	// It is not part of the wire format and is ignored by Marshal.
+	Arrival time.Time `fmt:"-"`
+
+	// paddingLength is the number of padding octets, count included, that
+	// ended the packet when it was decoded. They are kept out of
+	// ProfileExtensions and written back by Marshal, so that a decoded
+	// packet is reproduced byte for byte.
+	paddingLength int
 }
 
 const (
@@ -93,6 +103,10 @@ func (r SenderReport) Marshal() ([]byte, error) {
 	 *        +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
 
+	if err := checkPacketLength(r.len()); err != nil {
+		return nil, err
+	}
+
 	rawPacket := make([]byte, r.len())
 	packetBody := rawPacket[headerLength:]
 
@@ -117,6 +131,9 @@ func (r SenderReport) Marshal() ([]byte, error) {
 	}
 
 	copy(packetBody[offset:], r.ProfileExtensions)
+	if padding := r.padding(); padding != 0 {
+		rawPacket[len(rawPacket)-1] = uint8(padding)
+	}
 
 	hData, err := r.Header().Marshal()
 	if err != nil {
@@ -181,6 +198,14 @@ func (r *SenderReport) Unmarshal(rawPacket []byte) error {
 	}
 
 	packetBody := rawPacket[headerLength:]
+	if h.Padding {
+		padding, err := trailingPadding(rawPacket, headerLength+srHeaderLength)
+		if err != nil {
+			return err
+		}
+		r.paddingLength = padding
+		packetBody = rawPacket[headerLength : len(rawPacket)-padding]
+	}
 
 	r.SSRC = binary.BigEndian.Uint32(packetBody[srSSRCOffset:])
 	r.NTPTime = binary.BigEndian.Uint64(packetBody[srNTPOffset:])
@@ -215,6 +240,12 @@ func (r *SenderReport) Unmarshal(rawPacket []byte) error {
 	return nil
 }
 
+// Reset clears the SenderReport so it can be reused, keeping the capacity
+// of its Reports slice.
+func (r *SenderReport) Reset() {
+	*r = SenderReport{Reports: r.Reports[:0]}
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (r *SenderReport) DestinationSSRC() []uint32 {
 	out := make([]uint32, len(r.Reports)+1)
@@ -225,20 +256,73 @@ func (r *SenderReport) DestinationSSRC() []uint32 {
 	return out
 }
 
+// ReceptionReports returns the reception report blocks carried by this packet.
+func (r *SenderReport) ReceptionReports() []ReceptionReport {
+	return r.Reports
+}
+
+// AppendReports adds reports to the reception report blocks of r, as an
+// SFU does when folding the reception reports it got from downstream into
+// its upstream SenderReport. An SR holds at most 31 blocks; the blocks
+// that do not fit are returned, in order, for a follow-up ReceiverReport.
+// An error is returned, and r is left unchanged, if r already holds more
+// than 31 blocks.
+func (r *SenderReport) AppendReports(reports []ReceptionReport) ([]ReceptionReport, error) {
+	if len(r.Reports) > countMax {
+		return nil, errTooManyReports
+	}
+
+	n := countMax - len(r.Reports)
+	if n > len(reports) {
+		n = len(reports)
+	}
+	r.Reports = append(r.Reports, reports[:n]...)
+
+	if n == len(reports) {
+		return nil, nil
+	}
+	return reports[n:], nil
+}
+
+// Counters returns the sender's packet and octet counts, for graphing the
+// throughput of a stream. Both are 32-bit counters that wrap around to 0
+// and reset when the sender changes its SSRC, so rates should be computed
+// from the difference of successive reports as uint32 arithmetic, as
+// SendBitrate does, rather than from the raw values.
+func (r *SenderReport) Counters() (packets uint32, octets uint32) {
+	return r.PacketCount, r.OctetCount
+}
+
+// Validate reports likely mistakes in a SenderReport that Marshal accepts,
+// such as a zero reporter SSRC left by an uninitialized field.
+func (r *SenderReport) Validate() error {
+	if r.SSRC == 0 {
+		return errZeroReporterSSRC
+	}
+	return nil
+}
+
+// padding returns the number of padding octets Marshal writes after the
+// ProfileExtensions.
+func (r *SenderReport) padding() int {
+	return reusePadding(len(r.ProfileExtensions), r.paddingLength)
+}
+
 func (r *SenderReport) len() int {
 	repsLength := 0
 	for _, rep := range r.Reports {
 		repsLength += rep.len()
 	}
-	return headerLength + srHeaderLength + repsLength + len(r.ProfileExtensions)
+	return headerLength + srHeaderLength + repsLength + len(r.ProfileExtensions) + r.padding()
 }
 
 // Header returns the Header associated with this packet.
 func (r *SenderReport) Header() Header {
 	return Header{
-		Count:  uint8(len(r.Reports)),
-		Type:   TypeSenderReport,
-		Length: uint16((r.len() / 4) - 1),
+		Padding: r.padding() != 0,
+		Count:   uint8(len(r.Reports)),
+		Type:    TypeSenderReport,
+		Length:  uint16((r.len() / 4) - 1),
 	}
 }
 
@@ -1,6 +1,7 @@
 package rtcp
 
 import (
+	"bytes"
 	"errors"
 	"reflect"
 	"testing"
@@ -292,3 +293,236 @@ func TestSenderReportRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestSenderReportValidate(t *testing.T) {
+	if err := (&SenderReport{SSRC: 0x902f9e2e}).Validate(); err != nil {
+		t.Errorf("Validate() err = %v, want nil", err)
+	}
+	if got, want := (&SenderReport{}).Validate(), errZeroReporterSSRC; !errors.Is(got, want) {
+		t.Errorf("Validate() err = %v, want %v", got, want)
+	}
+}
+
+func TestSenderReportUnmarshalExtraBlocks(t *testing.T) {
+	// count=0, but the body holds a report block
+	data := []byte{
+		// v=2, p=0, count=0, SR, len=12
+		0x80, 0xc8, 0x0, 0xc,
+		// ssrc=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// ntp=0xda8bd1fcdddda05a
+		0xda, 0x8b, 0xd1, 0xfc,
+		0xdd, 0xdd, 0xa0, 0x5a,
+		// rtp=0xaaf4edd5
+		0xaa, 0xf4, 0xed, 0xd5,
+		// packetCount=1
+		0x00, 0x00, 0x00, 0x01,
+		// octetCount=2
+		0x00, 0x00, 0x00, 0x02,
+		// phantom block: ssrc=0xbc5e9a40
+		0xbc, 0x5e, 0x9a, 0x40,
+		0x0, 0x0, 0x0, 0x0,
+		0x0, 0x0, 0x46, 0xe1,
+		0x0, 0x0, 0x1, 0x11,
+		0x9, 0xf3, 0x64, 0x32,
+		0x0, 0x2, 0x4a, 0x79,
+	}
+
+	var sr SenderReport
+	if err := sr.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(sr.Reports) != 0 {
+		t.Fatalf("Unmarshal: got reports %#v, want none", sr.Reports)
+	}
+	if got, want := sr.ProfileExtensions, data[28:]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unmarshal: got profile extensions %#v, want %#v", got, want)
+	}
+}
+
+func TestSenderReportRemarshalByteStable(t *testing.T) {
+	data := []byte{
+		// v=2, p=1, count=2, SR, len=20
+		0xa2, 0xc8, 0x00, 0x14,
+		// ssrc=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// ntp=0xda8bd1fcdddda05a
+		0xda, 0x8b, 0xd1, 0xfc,
+		0xdd, 0xdd, 0xa0, 0x5a,
+		// rtp=0xaaf4edd5
+		0xaa, 0xf4, 0xed, 0xd5,
+		// packetCount=1, octetCount=2
+		0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x02,
+		// ssrc=0xbc5e9a40, then ssrc=0x12345678
+		0xbc, 0x5e, 0x9a, 0x40,
+		0x01, 0x00, 0x00, 0x02,
+		0x00, 0x00, 0x46, 0xe1,
+		0x00, 0x00, 0x01, 0x11,
+		0x09, 0xf3, 0x64, 0x32,
+		0x00, 0x02, 0x4a, 0x79,
+		0x12, 0x34, 0x56, 0x78,
+		0x03, 0x00, 0x00, 0x04,
+		0x00, 0x01, 0x00, 0x05,
+		0x00, 0x00, 0x00, 0x06,
+		0x00, 0x00, 0x00, 0x07,
+		0x00, 0x00, 0x00, 0x08,
+		// profile-specific extension data, then 4 bytes of padding
+		0x54, 0x45, 0x53, 0x54,
+		0x00, 0x00, 0x00, 0x04,
+	}
+
+	var r SenderReport
+	if err := r.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal err = %v", err)
+	}
+	if got, want := r.Reports[0].SSRC, uint32(0xbc5e9a40); got != want {
+		t.Fatalf("first report SSRC = %x, want %x", got, want)
+	}
+
+	remarshaled, err := r.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+	if !reflect.DeepEqual(remarshaled, data) {
+		t.Errorf("Marshal(Unmarshal(x)) = %x, want %x", remarshaled, data)
+	}
+}
+
+func TestSenderReportPaddingCount(t *testing.T) {
+	data := []byte{
+		// v=2, p=1, count=0, SR, len=8
+		0xa0, 0xc8, 0x00, 0x08,
+		// ssrc=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// ntp=0xda8bd1fcdddda05a
+		0xda, 0x8b, 0xd1, 0xfc,
+		0xdd, 0xdd, 0xa0, 0x5a,
+		// rtp=0xaaf4edd5
+		0xaa, 0xf4, 0xed, 0xd5,
+		// packetCount=1, octetCount=2
+		0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x02,
+		// profile-specific extension data, then 8 bytes of padding
+		0x54, 0x45, 0x53, 0x54,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x08,
+	}
+
+	var r SenderReport
+	if err := r.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal err = %v", err)
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatalf("Validate err = %v", err)
+	}
+
+	out, err := r.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+	if got, want := out[0]&0x20, byte(0x20); got != want {
+		t.Fatalf("padding bit not set in %x", out[0])
+	}
+	if got, want := out[len(out)-1], byte(8); got != want {
+		t.Fatalf("trailing byte = %d, want padding length %d", got, want)
+	}
+
+	if got, want := r.ProfileExtensions, data[28:32]; !bytes.Equal(got, want) {
+		t.Fatalf("ProfileExtensions = %x, want %x", got, want)
+	}
+
+	// Editing the extension keeps the padding count intact.
+	for _, ext := range [][]byte{nil, {0x01, 0x02}, {0x01, 0x02, 0x03, 0x04, 0x05, 0x06}} {
+		r.ProfileExtensions = ext
+		out, err := r.Marshal()
+		if err != nil {
+			t.Fatalf("Marshal(%x) err = %v", ext, err)
+		}
+		if out[0]&0x20 == 0 {
+			t.Fatalf("Marshal(%x): padding bit not set in %x", ext, out[0])
+		}
+		var decoded SenderReport
+		if err := decoded.Unmarshal(out); err != nil {
+			t.Fatalf("Unmarshal(%x) err = %v", out, err)
+		}
+		if got := decoded.ProfileExtensions; !bytes.Equal(got, ext) {
+			t.Fatalf("ProfileExtensions = %x, want %x", got, ext)
+		}
+		if got, want := decoded.OctetCount, uint32(2); got != want {
+			t.Fatalf("OctetCount = %d, want %d", got, want)
+		}
+	}
+}
+
+func TestSenderReportAppendReports(t *testing.T) {
+	blocks := make([]ReceptionReport, 40)
+	for i := range blocks {
+		blocks[i] = ReceptionReport{SSRC: uint32(i + 1)}
+	}
+
+	sr := SenderReport{SSRC: 0x902f9e2e, Reports: append([]ReceptionReport(nil), blocks[:5]...)}
+	overflow, err := sr.AppendReports(blocks[5:])
+	if err != nil {
+		t.Fatalf("AppendReports err = %v", err)
+	}
+	if got, want := sr.Reports, blocks[:31]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("SR reports = %v, want %v", got, want)
+	}
+	if got, want := overflow, blocks[31:]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("overflow = %v, want %v", got, want)
+	}
+	if _, err := sr.Marshal(); err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+
+	// A full SR takes nothing more.
+	overflow, err = sr.AppendReports(blocks[:1])
+	if err != nil {
+		t.Fatalf("AppendReports err = %v", err)
+	}
+	if got, want := overflow, blocks[:1]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("overflow = %v, want %v", got, want)
+	}
+
+	sr = SenderReport{SSRC: 0x902f9e2e}
+	overflow, err = sr.AppendReports(blocks[:3])
+	if err != nil || overflow != nil {
+		t.Fatalf("AppendReports = %v, %v, want nil, nil", overflow, err)
+	}
+
+	sr = SenderReport{Reports: blocks[:32]}
+	if _, err := sr.AppendReports(blocks[:1]); !errors.Is(err, errTooManyReports) {
+		t.Fatalf("AppendReports err = %v, want %v", err, errTooManyReports)
+	}
+	if got, want := len(sr.Reports), 32; got != want {
+		t.Fatalf("len(Reports) = %d, want %d", got, want)
+	}
+}
+
+func TestSenderReportCounters(t *testing.T) {
+	data := []byte{
+		// v=2, p=0, count=0, SR, len=6
+		0x80, 0xc8, 0x0, 0x6,
+		// ssrc=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// ntp=0xda8bd1fcdddda05a
+		0xda, 0x8b, 0xd1, 0xfc,
+		0xdd, 0xdd, 0xa0, 0x5a,
+		// rtp=0xaaf4edd5
+		0xaa, 0xf4, 0xed, 0xd5,
+		// packetCount=1000
+		0x00, 0x00, 0x03, 0xe8,
+		// octetCount=0xfffffff0
+		0xff, 0xff, 0xff, 0xf0,
+	}
+
+	var sr SenderReport
+	if err := sr.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	packets, octets := sr.Counters()
+	if packets != 1000 || octets != 0xfffffff0 {
+		t.Fatalf("Counters() = %d, %d, want 1000, %d", packets, octets, uint32(0xfffffff0))
+	}
+}
@@ -107,6 +107,21 @@ func (p *SliceLossIndication) String() string {
 	return fmt.Sprintf("SliceLossIndication %x %x %+v", p.SenderSSRC, p.MediaSSRC, p.SLI)
 }
 
+// Reset clears the SliceLossIndication so it can be reused, keeping the
+// capacity of its SLI slice.
+func (p *SliceLossIndication) Reset() {
+	*p = SliceLossIndication{SLI: p.SLI[:0]}
+}
+
+// Validate reports likely mistakes in a SliceLossIndication that Marshal
+// accepts, such as a zero media SSRC.
+func (p *SliceLossIndication) Validate() error {
+	if p.MediaSSRC == 0 {
+		return errZeroMediaSSRC
+	}
+	return nil
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (p *SliceLossIndication) DestinationSSRC() []uint32 {
 	return []uint32{p.MediaSSRC}
@@ -94,6 +94,10 @@ func (s SourceDescription) Marshal() ([]byte, error) {
 	 *        +=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+
 	 */
 
+	if err := checkPacketLength(s.len()); err != nil {
+		return nil, err
+	}
+
 	rawPacket := make([]byte, s.len())
 	packetBody := rawPacket[headerLength:]
 
@@ -149,9 +153,24 @@ func (s *SourceDescription) Unmarshal(rawPacket []byte) error {
 		return errWrongType
 	}
 
-	for i := headerLength; i < len(rawPacket); {
+	// Existing encoders do not always set the header length exactly, so
+	// rawPacket rather than h.Length bounds the chunks. Packet padding is
+	// removed, as its count octet is not null.
+	end := len(rawPacket)
+	if h.Padding {
+		padding, err := trailingPadding(rawPacket, headerLength)
+		if err != nil {
+			return err
+		}
+		end -= padding
+	}
+
+	// The header count bounds the number of chunks, so stop before
+	// parsing data beyond it rather than after.
+	i := headerLength
+	for i < end && len(s.Chunks) < int(h.Count) {
 		var chunk SourceDescriptionChunk
-		if err := chunk.Unmarshal(rawPacket[i:]); err != nil {
+		if err := chunk.Unmarshal(rawPacket[i:end]); err != nil {
 			return err
 		}
 		s.Chunks = append(s.Chunks, chunk)
@@ -163,6 +182,14 @@ func (s *SourceDescription) Unmarshal(rawPacket []byte) error {
 		return errInvalidHeader
 	}
 
+	// Whatever follows the last chunk can only be null octets aligning
+	// the packet; anything else is a chunk the count does not cover.
+	for _, b := range rawPacket[i:end] {
+		if b != 0 {
+			return errTooManyChunks
+		}
+	}
+
 	return nil
 }
 
@@ -201,6 +228,10 @@ func (s SourceDescriptionChunk) Marshal() ([]byte, error) {
 	 *  +=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+
 	 */
 
+	if s.Source == 0 {
+		return nil, errSDESZeroSource
+	}
+
 	rawPacket := make([]byte, sdesSourceLen)
 	binary.BigEndian.PutUint32(rawPacket, s.Source)
 
@@ -237,6 +268,9 @@ func (s *SourceDescriptionChunk) Unmarshal(rawPacket []byte) error {
 	}
 
 	s.Source = binary.BigEndian.Uint32(rawPacket)
+	if s.Source == 0 {
+		return errSDESZeroSource
+	}
 
 	for i := 4; i < len(rawPacket); {
 		if pktType := SDESType(rawPacket[i]); pktType == SDESEnd {
@@ -267,6 +301,30 @@ func (s SourceDescriptionChunk) len() int {
 	return len
 }
 
+// HasNote reports whether the chunk carries a NOTE item. RFC 3550 gives
+// NOTE transient semantics: it is set while a condition such as "away"
+// holds and dropped once it no longer does.
+func (s *SourceDescriptionChunk) HasNote() bool {
+	for _, it := range s.Items {
+		if it.Type == SDESNote {
+			return true
+		}
+	}
+	return false
+}
+
+// ClearNote removes any NOTE items from the chunk, keeping the order of the
+// remaining items.
+func (s *SourceDescriptionChunk) ClearNote() {
+	items := s.Items[:0]
+	for _, it := range s.Items {
+		if it.Type != SDESNote {
+			items = append(items, it)
+		}
+	}
+	s.Items = items
+}
+
 // A SourceDescriptionItem is a part of a SourceDescription that describes a stream.
 type SourceDescriptionItem struct {
 	// The type identifier for this item. eg, SDESCNAME for canonical name description.
@@ -345,6 +403,15 @@ func (s *SourceDescriptionItem) Unmarshal(rawPacket []byte) error {
 	return nil
 }
 
+// Reset clears the SourceDescription so it can be reused, keeping the
+// capacity of its Chunks slice.
+func (s *SourceDescription) Reset() {
+	for i := range s.Chunks {
+		s.Chunks[i] = SourceDescriptionChunk{}
+	}
+	s.Chunks = s.Chunks[:0]
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (s *SourceDescription) DestinationSSRC() []uint32 {
 	out := make([]uint32, len(s.Chunks))
@@ -354,10 +421,23 @@ func (s *SourceDescription) DestinationSSRC() []uint32 {
 	return out
 }
 
+// ForEachItem calls fn with the source and each item of every chunk, in
+// packet order.
+func (s *SourceDescription) ForEachItem(fn func(ssrc uint32, item SourceDescriptionItem)) {
+	for _, c := range s.Chunks {
+		for _, it := range c.Items {
+			fn(c.Source, it)
+		}
+	}
+}
+
 func (s *SourceDescription) String() string {
 	out := "Source Description:\n"
 	for _, c := range s.Chunks {
-		out += fmt.Sprintf("\t%x: %s\n", c.Source, c.Items)
+		out += fmt.Sprintf("\t%x:\n", c.Source)
+		for _, it := range c.Items {
+			out += fmt.Sprintf("\t\t%s: %s\n", it.Type, it.Text)
+		}
 	}
 	return out
 }
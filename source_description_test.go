@@ -35,8 +35,8 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 			Data: []byte{
 				// v=2, p=0, count=1, SDES, len=8
 				0x81, 0xca, 0x00, 0x08,
-				// ssrc=0x00000000
-				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
 			},
 			WantError: errPacketTooShort,
 		},
@@ -45,8 +45,8 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 			Data: []byte{
 				// v=2, p=0, count=1, SDES, len=10
 				0x81, 0xca, 0x00, 0x0a,
-				// ssrc=0x00000000
-				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
 				// CNAME, len = 1
 				0x01, 0x01,
 			},
@@ -57,8 +57,8 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 			Data: []byte{
 				// v=2, p=0, count=1, SDES, len=9
 				0x81, 0xca, 0x00, 0x09,
-				// ssrc=0x00000000
-				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
 				// CNAME, Missing length
 				0x01,
 			},
@@ -69,8 +69,8 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 			Data: []byte{
 				// v=2, p=0, count=1, SDES, len=11
 				0x81, 0xca, 0x00, 0x0b,
-				// ssrc=0x00000000
-				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
 				// CNAME, len=1, content=A
 				0x01, 0x02, 0x41,
 				// Missing END
@@ -82,8 +82,8 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 			Data: []byte{
 				// v=2, p=0, count=1, SDES, len=10
 				0x81, 0xca, 0x00, 0x0a,
-				// ssrc=0x00000000
-				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
 				// CNAME, len=1
 				0x01, 0x01,
 			},
@@ -126,6 +126,83 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 			},
 			WantError: errInvalidHeader,
 		},
+		{
+			Name: "zero source",
+			Data: []byte{
+				// v=2, p=0, count=1, SDES, len=2
+				0x81, 0xca, 0x00, 0x02,
+				// ssrc=0x00000000
+				0x00, 0x00, 0x00, 0x00,
+				// END + padding
+				0x00, 0x00, 0x00, 0x00,
+			},
+			WantError: errSDESZeroSource,
+		},
+		{
+			Name: "more chunks than count",
+			Data: []byte{
+				// v=2, p=0, count=1, SDES, len=4
+				0x81, 0xca, 0x00, 0x04,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// END + padding
+				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x05060708
+				0x05, 0x06, 0x07, 0x08,
+				// END + padding
+				0x00, 0x00, 0x00, 0x00,
+			},
+			WantError: errTooManyChunks,
+		},
+		{
+			Name: "alignment after last chunk",
+			Data: []byte{
+				// v=2, p=0, count=1, SDES, len=4
+				0x81, 0xca, 0x00, 0x04,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// CNAME, len=1, content=A
+				0x01, 0x01, 0x41,
+				// END + padding
+				0x00,
+				// null alignment octets
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			Want: *NewCNAMESourceDescription(0x01020304, "A"),
+		},
+		{
+			Name: "padding after last chunk",
+			Data: []byte{
+				// v=2, p=1, count=1, SDES, len=3
+				0xa1, 0xca, 0x00, 0x03,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// CNAME, len=1, content=A
+				0x01, 0x01, 0x41,
+				// END + padding
+				0x00,
+				// packet padding, count=4
+				0x00, 0x00, 0x00, 0x04,
+			},
+			Want: *NewCNAMESourceDescription(0x01020304, "A"),
+		},
+		{
+			Name: "bad padding count",
+			Data: []byte{
+				// v=2, p=1, count=1, SDES, len=3
+				0xa1, 0xca, 0x00, 0x03,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// CNAME, len=1, content=A
+				0x01, 0x01, 0x41,
+				// END + padding
+				0x00,
+				// packet padding, count=0
+				0x00, 0x00, 0x00, 0x00,
+			},
+			WantError: errWrongPadding,
+		},
 		{
 			Name: "empty string",
 			Data: []byte{
@@ -237,7 +314,7 @@ func TestSourceDescriptionRoundTrip(t *testing.T) {
 	var tooLongText string
 
 	for i := 0; i < (1 << 5); i++ {
-		tooManyChunks = append(tooManyChunks, SourceDescriptionChunk{})
+		tooManyChunks = append(tooManyChunks, SourceDescriptionChunk{Source: uint32(i + 1)})
 	}
 	for i := 0; i < (1 << 8); i++ {
 		tooLongText += "x"
@@ -313,10 +390,23 @@ func TestSourceDescriptionRoundTrip(t *testing.T) {
 			Name: "empty text",
 			Desc: *NewCNAMESourceDescription(1, ""),
 		},
+		{
+			Name: "zero source",
+			Desc: SourceDescription{
+				Chunks: []SourceDescriptionChunk{{
+					Items: []SourceDescriptionItem{{
+						Type: SDESCNAME,
+						Text: "cname",
+					}},
+				}},
+			},
+			WantError: errSDESZeroSource,
+		},
 		{
 			Name: "text too long",
 			Desc: SourceDescription{
 				Chunks: []SourceDescriptionChunk{{
+					Source: 1,
 					Items: []SourceDescriptionItem{{
 						Type: SDESCNAME,
 						Text: tooLongText,
@@ -351,3 +441,145 @@ func TestSourceDescriptionRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestSourceDescriptionMultiItemChunk(t *testing.T) {
+	sdes := SourceDescription{
+		Chunks: []SourceDescriptionChunk{
+			{
+				Source: 0x10000000,
+				Items: []SourceDescriptionItem{
+					{Type: SDESCNAME, Text: "cname"},
+					{Type: SDESName, Text: "ab"},
+					{Type: SDESTool, Text: "pion"},
+				},
+			},
+			{
+				Source: 0x20000000,
+				Items:  []SourceDescriptionItem{{Type: SDESCNAME, Text: "x"}},
+			},
+		},
+	}
+
+	want := []byte{
+		// v=2, p=0, count=2, SDES, len=8
+		0x82, 0xca, 0x00, 0x08,
+		// ssrc=0x10000000
+		0x10, 0x00, 0x00, 0x00,
+		// CNAME, len=5, "cname"
+		0x01, 0x05, 0x63, 0x6e, 0x61, 0x6d, 0x65,
+		// NAME, len=2, "ab"
+		0x02, 0x02, 0x61, 0x62,
+		// TOOL, len=4, "pion"
+		0x06, 0x04, 0x70, 0x69, 0x6f, 0x6e,
+		// end of list, padding to the next word
+		0x00, 0x00, 0x00,
+		// ssrc=0x20000000
+		0x20, 0x00, 0x00, 0x00,
+		// CNAME, len=1, "x", end of list
+		0x01, 0x01, 0x78, 0x00,
+	}
+
+	data, err := sdes.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Fatalf("Marshal: got %#v, want %#v", data, want)
+	}
+
+	var decoded SourceDescription
+	if err := decoded.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, sdes) {
+		t.Fatalf("round trip: got %#v, want %#v", decoded, sdes)
+	}
+}
+
+func TestSourceDescriptionChunkNote(t *testing.T) {
+	chunk := SourceDescriptionChunk{
+		Source: 0x10000000,
+		Items:  []SourceDescriptionItem{{Type: SDESCNAME, Text: "cname"}},
+	}
+	if chunk.HasNote() {
+		t.Fatalf("HasNote() = true before setting a note")
+	}
+
+	chunk.Items = append(chunk.Items,
+		SourceDescriptionItem{Type: SDESNote, Text: "on the phone"},
+		SourceDescriptionItem{Type: SDESTool, Text: "pion"},
+	)
+	if !chunk.HasNote() {
+		t.Fatalf("HasNote() = false after setting a note")
+	}
+
+	chunk.ClearNote()
+	if chunk.HasNote() {
+		t.Fatalf("HasNote() = true after ClearNote")
+	}
+	want := []SourceDescriptionItem{
+		{Type: SDESCNAME, Text: "cname"},
+		{Type: SDESTool, Text: "pion"},
+	}
+	if !reflect.DeepEqual(chunk.Items, want) {
+		t.Fatalf("ClearNote items = %#v, want %#v", chunk.Items, want)
+	}
+}
+
+func TestSourceDescriptionMarshalTooLong(t *testing.T) {
+	text := string(make([]byte, 254))
+	sdes := SourceDescription{}
+	for i := 0; i < countMax; i++ {
+		chunk := SourceDescriptionChunk{Source: uint32(i + 1)}
+		for k := 0; k < 40; k++ {
+			chunk.Items = append(chunk.Items, SourceDescriptionItem{Type: SDESNote, Text: text})
+		}
+		sdes.Chunks = append(sdes.Chunks, chunk)
+	}
+	if sdes.len() <= maxPacketLength {
+		t.Fatalf("test packet is only %d bytes", sdes.len())
+	}
+
+	if _, err := sdes.Marshal(); !errors.Is(err, errPacketTooLong) {
+		t.Fatalf("Marshal() err = %v, want %v", err, errPacketTooLong)
+	}
+
+	sdes.Chunks = sdes.Chunks[:1]
+	if _, err := sdes.Marshal(); err != nil {
+		t.Fatalf("Marshal() err = %v, want nil", err)
+	}
+}
+
+func TestSourceDescriptionForEachItem(t *testing.T) {
+	sdes := SourceDescription{
+		Chunks: []SourceDescriptionChunk{
+			{
+				Source: 0x10000000,
+				Items: []SourceDescriptionItem{
+					{Type: SDESCNAME, Text: "a"},
+					{Type: SDESTool, Text: "pion"},
+				},
+			},
+			{
+				Source: 0x20000000,
+				Items: []SourceDescriptionItem{
+					{Type: SDESCNAME, Text: "b"},
+				},
+			},
+		},
+	}
+
+	counts := map[SDESType]int{}
+	var sources []uint32
+	sdes.ForEachItem(func(ssrc uint32, item SourceDescriptionItem) {
+		counts[item.Type]++
+		sources = append(sources, ssrc)
+	})
+
+	if got, want := counts, map[SDESType]int{SDESCNAME: 2, SDESTool: 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("item counts = %v, want %v", got, want)
+	}
+	if got, want := sources, []uint32{0x10000000, 0x10000000, 0x20000000}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("sources = %v, want %v", got, want)
+	}
+}
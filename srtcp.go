@@ -0,0 +1,77 @@
+package rtcp
+
+import "encoding/binary"
+
+const (
+	srtcpIndexLength = 4
+	srtcpEncryptFlag = 1 << 31
+	srtcpIndexMask   = srtcpEncryptFlag - 1
+)
+
+// StripSRTCPTrailer removes the SRTCP trailer from a protected datagram, so
+// that the RTCP it carries can be handed to Unmarshal once decrypted.
+//
+// An SRTCP packet is laid out as the RTCP compound packet, followed by a
+// 32-bit word holding the E-flag and SRTCP index, followed by the
+// authentication tag. See RFC 3711, Section 3.4.
+//
+//  0                   1                   2                   3
+//  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// :                  RTCP compound packet ...                     :
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// |E|                         SRTCP index                         |
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+// :                     authentication tag                        :
+// +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// It returns the RTCP portion of packet, the SRTCP index and whether the
+// E-flag (encrypted) is set. The returned slice aliases packet.
+func StripSRTCPTrailer(packet []byte, authTagLen int) ([]byte, uint32, bool, error) {
+	if authTagLen < 0 {
+		return nil, 0, false, errInvalidAuthTagLength
+	}
+
+	if len(packet) < headerLength+srtcpIndexLength+authTagLen {
+		return nil, 0, false, errPacketTooShort
+	}
+
+	indexOffset := len(packet) - authTagLen - srtcpIndexLength
+	word := binary.BigEndian.Uint32(packet[indexOffset:])
+
+	return packet[:indexOffset], word & srtcpIndexMask, word&srtcpEncryptFlag != 0, nil
+}
+
+// LooksEncrypted guesses whether packet is an SRTCP packet, or otherwise
+// not plain RTCP, so callers sharing a flow between SRTCP and RTCP can
+// avoid handing ciphertext to Unmarshal. SRTCP leaves the first header
+// and SSRC in the clear, so only the first packet can be checked directly.
+// LooksEncrypted therefore also walks the chain of packet lengths: in
+// plain RTCP every header has version 2 and a type in the RTCP range, and
+// the lengths add up to the datagram exactly, while ciphertext and the
+// SRTCP trailer break the chain with high probability.
+func LooksEncrypted(packet []byte) bool {
+	if len(packet) < headerLength {
+		return false
+	}
+
+	for len(packet) != 0 {
+		if len(packet) < headerLength {
+			return true
+		}
+		var h Header
+		if err := h.Unmarshal(packet); err != nil {
+			return true
+		}
+		// RFC 5761 reserves 192-223 for RTCP
+		if h.Type < 192 || h.Type > 223 {
+			return true
+		}
+		n := int(h.Length+1) * 4
+		if n > len(packet) {
+			return true
+		}
+		packet = packet[n:]
+	}
+	return false
+}
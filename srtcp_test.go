@@ -0,0 +1,123 @@
+package rtcp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripSRTCPTrailer(t *testing.T) {
+	pli := []byte{
+		// v=2, p=0, FMT=1, PSFB, len=2
+		0x81, 0xce, 0x00, 0x02,
+		// sender=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// media=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+	}
+
+	for _, test := range []struct {
+		Name       string
+		Data       []byte
+		AuthTagLen int
+		WantRTCP   []byte
+		WantIndex  uint32
+		WantE      bool
+		WantError  error
+	}{
+		{
+			Name: "encrypted",
+			Data: append(append([]byte{}, pli...),
+				// E=1, index=5
+				0x80, 0x00, 0x00, 0x05,
+				// 80-bit auth tag
+				0xde, 0xad, 0xbe, 0xef, 0xca, 0xfe, 0xba, 0xbe, 0x01, 0x02,
+			),
+			AuthTagLen: 10,
+			WantRTCP:   pli,
+			WantIndex:  5,
+			WantE:      true,
+		},
+		{
+			Name: "unencrypted",
+			Data: append(append([]byte{}, pli...),
+				// E=0, index=0x7fffffff
+				0x7f, 0xff, 0xff, 0xff,
+				// 32-bit auth tag
+				0x01, 0x02, 0x03, 0x04,
+			),
+			AuthTagLen: 4,
+			WantRTCP:   pli,
+			WantIndex:  0x7fffffff,
+			WantE:      false,
+		},
+		{
+			Name: "no auth tag",
+			Data: append(append([]byte{}, pli...),
+				0x80, 0x00, 0x01, 0x00,
+			),
+			AuthTagLen: 0,
+			WantRTCP:   pli,
+			WantIndex:  0x100,
+			WantE:      true,
+		},
+		{
+			Name:       "too short",
+			Data:       pli,
+			AuthTagLen: 10,
+			WantError:  errPacketTooShort,
+		},
+		{
+			Name:       "negative auth tag length",
+			Data:       pli,
+			AuthTagLen: -1,
+			WantError:  errInvalidAuthTagLength,
+		},
+	} {
+		rtcp, index, encrypted, err := StripSRTCPTrailer(test.Data, test.AuthTagLen)
+		if got, want := err, test.WantError; !errors.Is(got, want) {
+			t.Fatalf("StripSRTCPTrailer(%q) err = %v, want %v", test.Name, got, want)
+		}
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, test.WantRTCP, rtcp, test.Name)
+		assert.Equal(t, test.WantIndex, index, test.Name)
+		assert.Equal(t, test.WantE, encrypted, test.Name)
+
+		if _, _, _, err := Unmarshal(rtcp); err != nil {
+			t.Fatalf("Unmarshal(%q) err = %v", test.Name, err)
+		}
+	}
+}
+
+func TestLooksEncrypted(t *testing.T) {
+	assert.False(t, LooksEncrypted(realPacket()))
+	assert.False(t, LooksEncrypted(realPacket()[92:104]))
+
+	// An SRTCP compound: RR header and SSRC in the clear, an encrypted
+	// report block and SDES, then the E-flag/index and a 10 byte tag.
+	srtcp := []byte{
+		0x81, 0xc9, 0x00, 0x09,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0x3e, 0x8a, 0x21, 0x9c, 0x57, 0x06, 0xd3, 0x4b,
+		0xf1, 0x6c, 0x0e, 0x95, 0x2a, 0xb7, 0x48, 0xe3,
+		0x79, 0x14, 0xca, 0x60, 0x8d, 0x3f, 0xb2, 0x05,
+		0x1b, 0xe9, 0x74, 0x52, 0xc6, 0x0a, 0x9f, 0x38,
+		0x80, 0x00, 0x00, 0x01,
+		0xd2, 0x4f, 0x91, 0x0c, 0x6b, 0xa8, 0x37, 0xe5, 0x1d, 0x70,
+	}
+	assert.True(t, LooksEncrypted(srtcp))
+
+	rtcp, _, encrypted, err := StripSRTCPTrailer(srtcp, 10)
+	assert.NoError(t, err)
+	assert.True(t, encrypted)
+	// The RTCP portion on its own still has a consistent length chain
+	assert.False(t, LooksEncrypted(rtcp))
+
+	// Bytes that are not RTCP at all
+	assert.True(t, LooksEncrypted([]byte{0x17, 0x03, 0x03, 0x00, 0x20, 0x00, 0x00, 0x00}))
+	assert.True(t, LooksEncrypted([]byte{0x80, 0x60, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00}))
+}
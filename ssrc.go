@@ -0,0 +1,27 @@
+package rtcp
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+)
+
+// GenerateSSRC returns a cryptographically random SSRC that is not in
+// existing, drawing again on every collision. Zero is never returned,
+// since Validate rejects it as a reporter SSRC. existing may be nil.
+//
+// GenerateSSRC panics if the system random source fails.
+func GenerateSSRC(existing map[uint32]struct{}) uint32 {
+	var b [ssrcLength]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			panic(err)
+		}
+		ssrc := binary.BigEndian.Uint32(b[:])
+		if ssrc == 0 {
+			continue
+		}
+		if _, taken := existing[ssrc]; !taken {
+			return ssrc
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSSRC(t *testing.T) {
+	assert.NotZero(t, GenerateSSRC(nil))
+
+	// Fill the set as it goes so later draws must avoid every earlier
+	// SSRC, including the pre-populated one.
+	existing := map[uint32]struct{}{0x902f9e2e: {}}
+	for i := 0; i < 1000; i++ {
+		ssrc := GenerateSSRC(existing)
+		_, dup := existing[ssrc]
+		assert.False(t, dup, "GenerateSSRC returned taken SSRC %x", ssrc)
+		assert.NotZero(t, ssrc)
+		existing[ssrc] = struct{}{}
+	}
+	assert.Len(t, existing, 1001)
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"time"
 )
 
 // https://tools.ietf.org/html/draft-holmer-rmcat-transport-wide-cc-extensions-01#page-5
@@ -346,8 +347,8 @@ type TransportLayerCC struct {
 // }
 // }
 
-func (t *TransportLayerCC) packetLen() uint16 {
-	n := uint16(headerLength + packetChunkOffset + len(t.PacketChunks)*2)
+func (t *TransportLayerCC) packetLen() int {
+	n := headerLength + packetChunkOffset + len(t.PacketChunks)*2
 	for _, d := range t.RecvDeltas {
 		if d.Type == TypeTCCPacketReceivedSmallDelta {
 			n++
@@ -358,8 +359,24 @@ func (t *TransportLayerCC) packetLen() uint16 {
 	return n
 }
 
+// computeHeader returns the Header describing the current contents of t
+func (t *TransportLayerCC) computeHeader() Header {
+	n := t.len()
+	return Header{
+		Padding: n != t.packetLen(),
+		Count:   FormatTCC,
+		Type:    TypeTransportSpecificFeedback,
+		// https://tools.ietf.org/html/rfc4585#page-33
+		Length: uint16(n/4 - 1),
+	}
+}
+
 // Len return total bytes with padding
 func (t *TransportLayerCC) Len() uint16 {
+	return uint16(t.len())
+}
+
+func (t *TransportLayerCC) len() int {
 	n := t.packetLen()
 	// has padding
 	if n%4 != 0 {
@@ -391,19 +408,26 @@ func (t TransportLayerCC) String() string {
 
 // Marshal encodes the TransportLayerCC in binary
 func (t TransportLayerCC) Marshal() ([]byte, error) {
-	header, err := t.Header.Marshal()
+	// The P bit follows the contents, not the stored header, so a stale
+	// Padding flag never makes the last receive delta read as a count.
+	if err := checkPacketLength(t.len()); err != nil {
+		return nil, err
+	}
+	padding := t.len() - t.packetLen()
+	h := t.Header
+	h.Padding = padding != 0
+	header, err := h.Marshal()
 	if err != nil {
 		return nil, err
 	}
 
-	payload := make([]byte, t.Len()-headerLength)
+	payload := make([]byte, t.len()-headerLength)
 	binary.BigEndian.PutUint32(payload, t.SenderSSRC)
 	binary.BigEndian.PutUint32(payload[4:], t.MediaSSRC)
 	binary.BigEndian.PutUint16(payload[baseSequenceNumberOffset:], t.BaseSequenceNumber)
 	binary.BigEndian.PutUint16(payload[packetStatusCountOffset:], t.PacketStatusCount)
-	ReferenceTimeAndFbPktCount := appendNBitsToUint32(0, 24, t.ReferenceTime)
-	ReferenceTimeAndFbPktCount = appendNBitsToUint32(ReferenceTimeAndFbPktCount, 8, uint32(t.FbPktCount))
-	binary.BigEndian.PutUint32(payload[referenceTimeOffset:], ReferenceTimeAndFbPktCount)
+	put24(payload[referenceTimeOffset:], t.ReferenceTime)
+	payload[fbPktCountOffset] = t.FbPktCount
 
 	for i, chunk := range t.PacketChunks {
 		b, err := chunk.Marshal()
@@ -426,8 +450,8 @@ func (t TransportLayerCC) Marshal() ([]byte, error) {
 		}
 	}
 
-	if t.Header.Padding {
-		payload[len(payload)-1] = uint8(t.Len() - t.packetLen())
+	if padding != 0 {
+		payload[len(payload)-1] = uint8(padding)
 	}
 
 	return append(header, payload...), nil
@@ -463,7 +487,7 @@ func (t *TransportLayerCC) Unmarshal(rawPacket []byte) error { //nolint:gocognit
 	t.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
 	t.BaseSequenceNumber = binary.BigEndian.Uint16(rawPacket[headerLength+baseSequenceNumberOffset:])
 	t.PacketStatusCount = binary.BigEndian.Uint16(rawPacket[headerLength+packetStatusCountOffset:])
-	t.ReferenceTime = get24BitsFromBytes(rawPacket[headerLength+referenceTimeOffset : headerLength+referenceTimeOffset+3])
+	t.ReferenceTime = get24(rawPacket[headerLength+referenceTimeOffset:])
 	t.FbPktCount = rawPacket[headerLength+fbPktCountOffset]
 
 	packetStatusPos := uint16(headerLength + packetChunkOffset)
@@ -483,9 +507,21 @@ func (t *TransportLayerCC) Unmarshal(rawPacket []byte) error { //nolint:gocognit
 				return err
 			}
 
-			packetNumberToProcess := min(t.PacketStatusCount-processedPacketNum, packetStatus.RunLength)
+			// Encoders may end the chunks with a run of not received
+			// packets reaching past the status count, but a run of
+			// received packets there would have no receive deltas.
+			remaining := t.PacketStatusCount - processedPacketNum
+			if packetStatus.RunLength > remaining && packetStatus.PacketStatusSymbol != TypeTCCPacketNotReceived {
+				return errPacketStatusCount
+			}
+			packetNumberToProcess := min(remaining, packetStatus.RunLength)
 			if packetStatus.PacketStatusSymbol == TypeTCCPacketReceivedSmallDelta ||
 				packetStatus.PacketStatusSymbol == TypeTCCPacketReceivedLargeDelta {
+				// Every received packet carries a delta of at least one
+				// byte, so a run longer than the packet is bogus.
+				if len(t.RecvDeltas)+int(packetNumberToProcess) > int(totalLength) {
+					return errTooManyRecvDeltas
+				}
 				for j := uint16(0); j < packetNumberToProcess; j++ {
 					t.RecvDeltas = append(t.RecvDeltas, &RecvDelta{Type: packetStatus.PacketStatusSymbol})
 				}
@@ -498,6 +534,13 @@ func (t *TransportLayerCC) Unmarshal(rawPacket []byte) error { //nolint:gocognit
 			if err != nil {
 				return err
 			}
+			// Likewise, the symbols of the last vector past the status
+			// count must all be not received.
+			for _, symbol := range packetStatus.SymbolList[min(uint16(len(packetStatus.SymbolList)), t.PacketStatusCount-processedPacketNum):] {
+				if symbol != TypeTCCPacketNotReceived {
+					return errPacketStatusCount
+				}
+			}
 			if packetStatus.SymbolSize == TypeTCCSymbolSizeOneBit {
 				for j := 0; j < len(packetStatus.SymbolList); j++ {
 					if packetStatus.SymbolList[j] == TypeTCCPacketReceivedSmallDelta {
@@ -542,6 +585,90 @@ func (t *TransportLayerCC) Unmarshal(rawPacket []byte) error { //nolint:gocognit
 	return nil
 }
 
+// Reset clears the TransportLayerCC so it can be reused, keeping the
+// capacity of its PacketChunks and RecvDeltas slices.
+func (t *TransportLayerCC) Reset() {
+	for i := range t.PacketChunks {
+		t.PacketChunks[i] = nil
+	}
+	for i := range t.RecvDeltas {
+		t.RecvDeltas[i] = nil
+	}
+	*t = TransportLayerCC{
+		PacketChunks: t.PacketChunks[:0],
+		RecvDeltas:   t.RecvDeltas[:0],
+	}
+}
+
+// ArrivalMap returns the arrival time of every packet t reports as
+// received, keyed by transport-wide sequence number and measured from the
+// reference time, for delay-based congestion controllers that pair them
+// with send times. Packets reported as not received are absent.
+func (t *TransportLayerCC) ArrivalMap() map[uint16]time.Duration {
+	out := make(map[uint16]time.Duration, len(t.RecvDeltas))
+	deltas := t.RecvDeltas
+	var offset time.Duration
+
+	t.forEachStatus(func(seq, symbol uint16) {
+		if (symbol == TypeTCCPacketReceivedSmallDelta || symbol == TypeTCCPacketReceivedLargeDelta) && len(deltas) != 0 {
+			offset += time.Duration(deltas[0].Delta) * time.Microsecond
+			out[seq] = offset
+			deltas = deltas[1:]
+		}
+	})
+	return out
+}
+
+// LostRanges returns the inclusive ranges of sequence numbers that t
+// reports as not received, in sequence number order.
+func (t *TransportLayerCC) LostRanges() [][2]uint16 {
+	var ranges [][2]uint16
+	inGap := false
+
+	t.forEachStatus(func(seq, symbol uint16) {
+		if symbol != TypeTCCPacketNotReceived {
+			inGap = false
+			return
+		}
+		if inGap {
+			ranges[len(ranges)-1][1] = seq
+			return
+		}
+		ranges = append(ranges, [2]uint16{seq, seq})
+		inGap = true
+	})
+	return ranges
+}
+
+// forEachStatus calls fn with the sequence number and status symbol of
+// each of the PacketStatusCount packets described by the chunks of t.
+func (t *TransportLayerCC) forEachStatus(fn func(seq, symbol uint16)) {
+	seq := t.BaseSequenceNumber
+	remaining := t.PacketStatusCount
+
+	visit := func(symbol uint16) {
+		if remaining == 0 {
+			return
+		}
+		fn(seq, symbol)
+		seq++
+		remaining--
+	}
+
+	for _, chunk := range t.PacketChunks {
+		switch c := chunk.(type) {
+		case *RunLengthChunk:
+			for i := uint16(0); i < c.RunLength && remaining != 0; i++ {
+				visit(c.PacketStatusSymbol)
+			}
+		case *StatusVectorChunk:
+			for _, symbol := range c.SymbolList {
+				visit(symbol)
+			}
+		}
+	}
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (t TransportLayerCC) DestinationSSRC() []uint32 {
 	return []uint32{t.MediaSSRC}
@@ -1,8 +1,10 @@
 package rtcp
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 )
 
 var _ Packet = (*TransportLayerCC)(nil) // assert is a Packet
@@ -670,6 +672,27 @@ func TestTransportLayerCC_Unmarshal(t *testing.T) {
 	}
 }
 
+func TestTransportLayerCC_UnmarshalTooManyRecvDeltas(t *testing.T) {
+	data := []byte{
+		0x8f, 0xcd, 0x0, 0x5,
+		0xfa, 0x17, 0xfa, 0x17,
+		0x43, 0x3, 0x2f, 0xa0,
+		// base sequence number 0, packet status count 8191
+		0x0, 0x0, 0x1f, 0xff,
+		0x3d, 0xe8, 0x2, 0x17,
+		// run of 8191 small deltas, followed by a single delta
+		0x3f, 0xff, 0x94, 0x0,
+	}
+
+	var p TransportLayerCC
+	if err := p.Unmarshal(data); !errors.Is(err, errTooManyRecvDeltas) {
+		t.Fatalf("Unmarshal err = %v, want %v", err, errTooManyRecvDeltas)
+	}
+	if len(p.RecvDeltas) != 0 {
+		t.Fatalf("Unmarshal allocated %d deltas before failing", len(p.RecvDeltas))
+	}
+}
+
 func TestTransportLayerCC_Marshal(t *testing.T) {
 	for _, test := range []struct {
 		Name      string
@@ -823,7 +846,9 @@ func TestTransportLayerCC_Marshal(t *testing.T) {
 				},
 			},
 			Want: []byte{
-				0xaf, 0xcd, 0x0, 0x7,
+				// The body is already aligned, so P is clear despite
+				// the stale Padding flag in the stored header.
+				0x8f, 0xcd, 0x0, 0x7,
 				0xfa, 0x17, 0xfa, 0x17,
 				0x19, 0x3d, 0xd8, 0xbb,
 				0x1, 0x74, 0x0, 0x6,
@@ -1030,3 +1055,248 @@ func TestTransportLayerCC_Marshal(t *testing.T) {
 		})
 	}
 }
+
+func TestTransportLayerCC_ArrivalMap(t *testing.T) {
+	data, err := Marshal([]Packet{&TransportLayerCC{
+		SenderSSRC:         4195875351,
+		MediaSSRC:          1124282272,
+		BaseSequenceNumber: 100,
+		PacketStatusCount:  7,
+		ReferenceTime:      4057090,
+		PacketChunks: []PacketStatusChunk{
+			&RunLengthChunk{
+				Type:               TypeTCCRunLengthChunk,
+				PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta,
+				RunLength:          2,
+			},
+			&StatusVectorChunk{
+				Type:       TypeTCCStatusVectorChunk,
+				SymbolSize: TypeTCCSymbolSizeTwoBit,
+				SymbolList: []uint16{
+					TypeTCCPacketNotReceived,
+					TypeTCCPacketReceivedLargeDelta,
+					TypeTCCPacketReceivedSmallDelta,
+					TypeTCCPacketNotReceived,
+					TypeTCCPacketReceivedSmallDelta,
+					TypeTCCPacketNotReceived,
+					TypeTCCPacketNotReceived,
+				},
+			},
+		},
+		RecvDeltas: []*RecvDelta{
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 1000},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 250},
+			{Type: TypeTCCPacketReceivedLargeDelta, Delta: -500},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 750},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 250},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+
+	var p TransportLayerCC
+	if err := p.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal err = %v", err)
+	}
+
+	want := map[uint16]time.Duration{
+		100: 1000 * time.Microsecond,
+		101: 1250 * time.Microsecond,
+		103: 750 * time.Microsecond,
+		104: 1500 * time.Microsecond,
+		106: 1750 * time.Microsecond,
+	}
+	if got := p.ArrivalMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ArrivalMap() = %v, want %v", got, want)
+	}
+}
+
+func TestTransportLayerCC_LostRanges(t *testing.T) {
+	data, err := Marshal([]Packet{&TransportLayerCC{
+		SenderSSRC:         4195875351,
+		MediaSSRC:          1124282272,
+		BaseSequenceNumber: 200,
+		PacketStatusCount:  10,
+		ReferenceTime:      4057090,
+		PacketChunks: []PacketStatusChunk{
+			&RunLengthChunk{
+				Type:               TypeTCCRunLengthChunk,
+				PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta,
+				RunLength:          2,
+			},
+			&RunLengthChunk{
+				Type:               TypeTCCRunLengthChunk,
+				PacketStatusSymbol: TypeTCCPacketNotReceived,
+				RunLength:          3,
+			},
+			&StatusVectorChunk{
+				Type:       TypeTCCStatusVectorChunk,
+				SymbolSize: TypeTCCSymbolSizeTwoBit,
+				SymbolList: []uint16{
+					TypeTCCPacketReceivedSmallDelta,
+					TypeTCCPacketNotReceived,
+					TypeTCCPacketNotReceived,
+					TypeTCCPacketReceivedSmallDelta,
+					TypeTCCPacketReceivedSmallDelta,
+					TypeTCCPacketNotReceived,
+					TypeTCCPacketNotReceived,
+				},
+			},
+		},
+		RecvDeltas: []*RecvDelta{
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 1000},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 250},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 250},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 250},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 250},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+
+	var p TransportLayerCC
+	if err := p.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal err = %v", err)
+	}
+
+	// The trailing not-received symbols of the vector chunk lie beyond
+	// PacketStatusCount and are not reported.
+	want := [][2]uint16{{202, 204}, {206, 207}}
+	if got := p.LostRanges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("LostRanges() = %v, want %v", got, want)
+	}
+}
+
+func TestTransportLayerCC_UnmarshalStatusCountMismatch(t *testing.T) {
+	for _, test := range []struct {
+		Name string
+		Data []byte
+	}{
+		{
+			Name: "run of received packets past the count",
+			Data: []byte{
+				0x8f, 0xcd, 0x0, 0x7,
+				0xfa, 0x17, 0xfa, 0x17,
+				0x43, 0x3, 0x2f, 0xa0,
+				// base sequence number 100, packet status count 10
+				0x0, 0x64, 0x0, 0xa,
+				0x3d, 0xe8, 0x2, 0x17,
+				// run of 12 small deltas
+				0x20, 0xc, 0x1, 0x1,
+				0x1, 0x1, 0x1, 0x1,
+				0x1, 0x1, 0x1, 0x1,
+			},
+		},
+		{
+			Name: "received symbols past the count",
+			Data: []byte{
+				0x8f, 0xcd, 0x0, 0x6,
+				0xfa, 0x17, 0xfa, 0x17,
+				0x43, 0x3, 0x2f, 0xa0,
+				// base sequence number 100, packet status count 3
+				0x0, 0x64, 0x0, 0x3,
+				0x3d, 0xe8, 0x2, 0x17,
+				// two-bit vector: 4 small deltas, then not received
+				0xd5, 0x40, 0x1, 0x1,
+				0x1, 0x1, 0x0, 0x0,
+			},
+		},
+	} {
+		var p TransportLayerCC
+		if err := p.Unmarshal(test.Data); !errors.Is(err, errPacketStatusCount) {
+			t.Errorf("%s: Unmarshal err = %v, want %v", test.Name, err, errPacketStatusCount)
+		}
+	}
+}
+
+func TestTransportLayerCC_MarshalPaddingBit(t *testing.T) {
+	packet := func(deltas int, padding bool) TransportLayerCC {
+		p := TransportLayerCC{
+			Header: Header{
+				Padding: padding,
+				Count:   FormatTCC,
+				Type:    TypeTransportSpecificFeedback,
+			},
+			SenderSSRC:        4195875351,
+			MediaSSRC:         1124282272,
+			PacketStatusCount: uint16(deltas),
+			PacketChunks: []PacketStatusChunk{
+				&RunLengthChunk{
+					Type:               TypeTCCRunLengthChunk,
+					PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta,
+					RunLength:          uint16(deltas),
+				},
+			},
+		}
+		for i := 0; i < deltas; i++ {
+			p.RecvDeltas = append(p.RecvDeltas, &RecvDelta{Type: TypeTCCPacketReceivedSmallDelta, Delta: 250 * int64(i+1)})
+		}
+		p.Header.Length = p.Len()/4 - 1
+		return p
+	}
+
+	// Aligned body with a stale Padding flag: P must be clear and the last
+	// octet is the last receive delta, not a padding count.
+	data, err := packet(2, true).Marshal()
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+	if data[0]&0x20 != 0 {
+		t.Fatalf("aligned: P bit set in %#x", data[0])
+	}
+	if got, want := data[len(data)-1], byte(2); got != want {
+		t.Fatalf("aligned: last octet = %d, want delta %d", got, want)
+	}
+
+	// Unaligned body without the flag: P is set and the count written.
+	data, err = packet(4, false).Marshal()
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+	if data[0]&0x20 == 0 {
+		t.Fatalf("unaligned: P bit clear in %#x", data[0])
+	}
+	if got, want := data[len(data)-1], byte(2); got != want {
+		t.Fatalf("unaligned: padding count = %d, want %d", got, want)
+	}
+
+	var decoded TransportLayerCC
+	if err := decoded.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal err = %v", err)
+	}
+	if got, want := len(decoded.RecvDeltas), 4; got != want {
+		t.Fatalf("Unmarshal: %d deltas, want %d", got, want)
+	}
+}
+
+func TestTransportLayerCC_MarshalLength(t *testing.T) {
+	packet := func(deltas int) *TransportLayerCC {
+		p := &TransportLayerCC{SenderSSRC: 1, MediaSSRC: 2}
+		for i := 0; i < deltas; i++ {
+			p.RecvDeltas = append(p.RecvDeltas, &RecvDelta{Type: TypeTCCPacketReceivedLargeDelta, Delta: -1000})
+		}
+		return p
+	}
+
+	// More octets than a uint16 holds, but within the header length field
+	data, err := Marshal([]Packet{packet(40000)})
+	if err != nil {
+		t.Fatalf("Marshal err = %v", err)
+	}
+	if got, want := len(data), headerLength+packetChunkOffset+80000; got != want {
+		t.Fatalf("len(Marshal) = %d, want %d", got, want)
+	}
+	var h Header
+	if err := h.Unmarshal(data); err != nil {
+		t.Fatalf("Header.Unmarshal err = %v", err)
+	}
+	if got, want := int(h.Length+1)*4, len(data); got != want {
+		t.Fatalf("header length = %d octets, want %d", got, want)
+	}
+
+	if _, err := packet(140000).Marshal(); !errors.Is(err, errPacketTooLong) {
+		t.Fatalf("Marshal err = %v, want %v", err, errPacketTooLong)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"sort"
 )
 
 // PacketBitmap shouldn't be used like a normal integral,
@@ -44,6 +45,12 @@ func NackPairsFromSequenceNumbers(sequenceNumbers []uint16) (pairs []NackPair) {
 	for i := 1; i < len(sequenceNumbers); i++ {
 		m := sequenceNumbers[i]
 
+		// Bit i of the bitmask is PacketID+i+1, so a repeat of the
+		// PacketID itself has no bit and is already covered.
+		if m == nackPair.PacketID {
+			continue
+		}
+
 		if m-nackPair.PacketID > 16 {
 			pairs = append(pairs, *nackPair)
 			nackPair = &NackPair{PacketID: m}
@@ -56,6 +63,20 @@ func NackPairsFromSequenceNumbers(sequenceNumbers []uint16) (pairs []NackPair) {
 	return
 }
 
+// QuickNack builds and marshals a reduced-size (RFC 5506) datagram holding
+// a single TransportLayerNack from senderSSRC that requests the
+// retransmission of the lost packets of mediaSSRC, for receivers that send
+// feedback as soon as loss is detected rather than in the next compound
+// packet. As with NackPairsFromSequenceNumbers, lost must be in ascending
+// order.
+func QuickNack(senderSSRC, mediaSSRC uint32, lost []uint16) ([]byte, error) {
+	return TransportLayerNack{
+		SenderSSRC: senderSSRC,
+		MediaSSRC:  mediaSSRC,
+		Nacks:      NackPairsFromSequenceNumbers(lost),
+	}.Marshal()
+}
+
 // Range calls f sequentially for each sequence number covered by n.
 // If f returns false, Range stops the iteration.
 func (n *NackPair) Range(f func(seqno uint16) bool) {
@@ -76,7 +97,9 @@ func (n *NackPair) Range(f func(seqno uint16) bool) {
 	}
 }
 
-// PacketList returns a list of Nack'd packets that's referenced by a NackPair
+// PacketList returns a list of Nack'd packets that's referenced by a NackPair.
+// The PacketID is always first, followed by PacketID+i+1 for each bit i set
+// in LostPackets.
 func (n *NackPair) PacketList() []uint16 {
 	out := make([]uint16, 0, 17)
 	n.Range(func(seqno uint16) bool {
@@ -115,7 +138,7 @@ func (p TransportLayerNack) Marshal() ([]byte, error) {
 
 // Unmarshal decodes the TransportLayerNack from binary
 func (p *TransportLayerNack) Unmarshal(rawPacket []byte) error {
-	if len(rawPacket) < (headerLength + ssrcLength) {
+	if len(rawPacket) < (headerLength + nackOffset) {
 		return errPacketTooShort
 	}
 
@@ -132,9 +155,18 @@ func (p *TransportLayerNack) Unmarshal(rawPacket []byte) error {
 		return errWrongType
 	}
 
+	n, err := contentLength(h, rawPacket)
+	if err != nil {
+		return err
+	}
+	// Both SSRCs are required; the list of NACK pairs may be empty.
+	if n < headerLength+nackOffset {
+		return errPacketTooShort
+	}
+
 	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
 	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
-	for i := headerLength + nackOffset; i < (headerLength + int(h.Length*4)); i += 4 {
+	for i := headerLength + nackOffset; i+4 <= n; i += 4 {
 		p.Nacks = append(p.Nacks, NackPair{
 			binary.BigEndian.Uint16(rawPacket[i:]),
 			PacketBitmap(binary.BigEndian.Uint16(rawPacket[i+2:])),
@@ -166,7 +198,51 @@ func (p TransportLayerNack) String() string {
 	return out
 }
 
+// Reset clears the TransportLayerNack so it can be reused, keeping the
+// capacity of its Nacks slice.
+func (p *TransportLayerNack) Reset() {
+	*p = TransportLayerNack{Nacks: p.Nacks[:0]}
+}
+
+// Normalize rewrites Nacks as the shortest list of NackPairs covering the
+// same sequence numbers, in ascending order. The grouping into pairs
+// carries no meaning, so two NACKs requesting the same packets marshal
+// identically once normalized.
+func (p *TransportLayerNack) Normalize() {
+	seen := make(map[uint16]struct{})
+	var seqs []uint16
+	p.ForEachLost(func(_ uint32, seq uint16) {
+		if _, ok := seen[seq]; !ok {
+			seen[seq] = struct{}{}
+			seqs = append(seqs, seq)
+		}
+	})
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	p.Nacks = NackPairsFromSequenceNumbers(seqs)
+}
+
+// Validate reports likely mistakes in a TransportLayerNack that Marshal
+// accepts, such as a zero media SSRC.
+func (p *TransportLayerNack) Validate() error {
+	if p.MediaSSRC == 0 {
+		return errZeroMediaSSRC
+	}
+	return nil
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (p *TransportLayerNack) DestinationSSRC() []uint32 {
 	return []uint32{p.MediaSSRC}
 }
+
+// ForEachLost calls fn with the media SSRC and each sequence number the
+// NACK requests, in packet order, without allocating. Senders can use it
+// to look up the RTX packet for every requested sequence number.
+func (p *TransportLayerNack) ForEachLost(fn func(ssrc uint32, seq uint16)) {
+	for i := range p.Nacks {
+		p.Nacks[i].Range(func(seq uint16) bool {
+			fn(p.MediaSSRC, seq)
+			return true
+		})
+	}
+}
@@ -33,6 +33,40 @@ func TestTransportLayerNackUnmarshal(t *testing.T) {
 				Nacks:      []NackPair{{0xaaaa, 0x5555}},
 			},
 		},
+		{
+			Name: "padded",
+			Data: []byte{
+				// TransportLayerNack, p=1
+				0xa1, 0xcd, 0x0, 0x4,
+				// sender=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// media=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// nack 0xAAAA, 0x5555
+				0xaa, 0xaa, 0x55, 0x55,
+				// padding
+				0x00, 0x00, 0x00, 0x04,
+			},
+			Want: TransportLayerNack{
+				SenderSSRC: 0x902f9e2e,
+				MediaSSRC:  0x902f9e2e,
+				Nacks:      []NackPair{{0xaaaa, 0x5555}},
+			},
+		},
+		{
+			Name: "padding longer than packet",
+			Data: []byte{
+				// TransportLayerNack, p=1
+				0xa1, 0xcd, 0x0, 0x3,
+				// sender=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// media=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// padding
+				0x00, 0x00, 0x00, 0x20,
+			},
+			WantError: errWrongPadding,
+		},
 		{
 			Name: "short report",
 			Data: []byte{
@@ -135,6 +169,26 @@ func TestNackPair(t *testing.T) {
 	testNackPair(t, []uint16{42, 42 + 16}, NackPair{42, 0x8000})
 }
 
+func TestNackPairBitmaskOffsets(t *testing.T) {
+	for bit := uint16(0); bit < 16; bit++ {
+		testNackPair(t, []uint16{42, 42 + bit + 1}, NackPair{42, PacketBitmap(1 << bit)})
+	}
+
+	// The PacketID is included even when every mask bit is set, and a
+	// mask that wraps the sequence space continues from 0.
+	n := NackPair{0xfff8, 0xffff}
+	l := n.PacketList()
+	if len(l) != 17 || l[0] != 0xfff8 || l[8] != 0 || l[16] != 8 {
+		t.Errorf("unexpected packet list %v", l)
+	}
+
+	// Repeating the PacketID must not set a bit for it.
+	pairs := NackPairsFromSequenceNumbers([]uint16{42, 42, 43})
+	if !reflect.DeepEqual(pairs, []NackPair{{42, 1}}) {
+		t.Errorf("unexpected pairs %v", pairs)
+	}
+}
+
 func TestNackPairRange(t *testing.T) {
 	n := NackPair{42, 2}
 
@@ -198,3 +252,107 @@ func TestTransportLayerNackPairGeneration(t *testing.T) {
 		}
 	}
 }
+
+func TestTransportLayerNackForEachLost(t *testing.T) {
+	p := TransportLayerNack{
+		SenderSSRC: 0x902f9e2e,
+		MediaSSRC:  0x4bc4fcb4,
+		Nacks: []NackPair{
+			{PacketID: 100, LostPackets: 0x4011},
+			{PacketID: 500, LostPackets: 0x3},
+		},
+	}
+
+	var got []uint16
+	p.ForEachLost(func(ssrc uint32, seq uint16) {
+		if ssrc != p.MediaSSRC {
+			t.Errorf("ForEachLost ssrc = %x, want %x", ssrc, p.MediaSSRC)
+		}
+		got = append(got, seq)
+	})
+	if want := []uint16{100, 101, 105, 115, 500, 501, 502}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ForEachLost sequence numbers = %v, want %v", got, want)
+	}
+}
+
+func TestTransportLayerNackNormalize(t *testing.T) {
+	a := &TransportLayerNack{
+		SenderSSRC: 1,
+		MediaSSRC:  2,
+		Nacks:      []NackPair{{PacketID: 120}, {PacketID: 100, LostPackets: 0x0003}},
+	}
+	b := &TransportLayerNack{
+		SenderSSRC: 1,
+		MediaSSRC:  2,
+		Nacks:      []NackPair{{PacketID: 101, LostPackets: 0x0001}, {PacketID: 100}, {PacketID: 120}},
+	}
+	if !SemanticEqual(a, b) {
+		t.Fatal("SemanticEqual = false for NACKs requesting the same packets")
+	}
+
+	b.Normalize()
+	if got, want := b.Nacks, []NackPair{{PacketID: 100, LostPackets: 0x0003}, {PacketID: 120}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Normalize: got %v, want %v", got, want)
+	}
+
+	c := &TransportLayerNack{SenderSSRC: 1, MediaSSRC: 3, Nacks: b.Nacks}
+	if SemanticEqual(a, c) {
+		t.Fatal("SemanticEqual = true for NACKs about different media SSRCs")
+	}
+}
+
+func TestTransportLayerNackEmptyFCI(t *testing.T) {
+	// v=2, p=0, FMT=1, RTPFB, len=2: both SSRCs and no NACK pairs
+	data := []byte{
+		0x81, 0xcd, 0x0, 0x2,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0x90, 0x2f, 0x9e, 0x2f,
+	}
+
+	packets, _, _, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got, want := packets, []Packet{&TransportLayerNack{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x902f9e2f}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unmarshal: got %#v, want %#v", got, want)
+	}
+
+	remarshaled, err := packets[0].Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !reflect.DeepEqual(remarshaled, data) {
+		t.Fatalf("Marshal: got %#v, want %#v", remarshaled, data)
+	}
+
+	// Without the media SSRC the packet is truncated rather than empty.
+	var nack TransportLayerNack
+	if err := nack.Unmarshal([]byte{0x81, 0xcd, 0x0, 0x1, 0x90, 0x2f, 0x9e, 0x2e}); !errors.Is(err, errPacketTooShort) {
+		t.Fatalf("Unmarshal without media SSRC: err = %v, want %v", err, errPacketTooShort)
+	}
+}
+
+func TestQuickNack(t *testing.T) {
+	data, err := QuickNack(0x902f9e2e, 0xbc5e9a40, []uint16{100, 101, 103, 200})
+	if err != nil {
+		t.Fatalf("QuickNack: %v", err)
+	}
+
+	standalone, typ, err := IsStandaloneFeedback(data)
+	if err != nil || !standalone || typ != TypeTransportSpecificFeedback {
+		t.Fatalf("IsStandaloneFeedback = %v, %v, %v, want true, %v, nil", standalone, typ, err, TypeTransportSpecificFeedback)
+	}
+
+	packets, _, _, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	want := []Packet{&TransportLayerNack{
+		SenderSSRC: 0x902f9e2e,
+		MediaSSRC:  0xbc5e9a40,
+		Nacks:      []NackPair{{PacketID: 100, LostPackets: 0x0005}, {PacketID: 200}},
+	}}
+	if !reflect.DeepEqual(packets, want) {
+		t.Fatalf("Unmarshal: got %#v, want %#v", packets, want)
+	}
+}
@@ -0,0 +1,42 @@
+package rtcp
+
+import "sync"
+
+// TWCCSequencer hands out the 8-bit feedback packet count carried by
+// TransportLayerCC packets. Each media SSRC has its own counter, which
+// starts at 0 and wraps from 255 back to 0.
+//
+// The zero value is ready to use, and a TWCCSequencer is safe for
+// concurrent use.
+type TWCCSequencer struct {
+	mu   sync.Mutex
+	next map[uint32]uint8
+}
+
+// Next returns the feedback packet count to use for the next
+// TransportLayerCC sent about mediaSSRC.
+func (s *TWCCSequencer) Next(mediaSSRC uint32) uint8 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.next == nil {
+		s.next = make(map[uint32]uint8)
+	}
+	count := s.next[mediaSSRC]
+	s.next[mediaSSRC] = count + 1
+	return count
+}
+
+// Stamp sets t.FbPktCount to the next feedback packet count for
+// t.MediaSSRC.
+func (s *TWCCSequencer) Stamp(t *TransportLayerCC) {
+	t.FbPktCount = s.Next(t.MediaSSRC)
+}
+
+// Reset restarts the counter of every media SSRC at 0.
+func (s *TWCCSequencer) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.next = nil
+}
@@ -0,0 +1,44 @@
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTWCCSequencerWraparound(t *testing.T) {
+	var s TWCCSequencer
+	for i := 0; i < 256; i++ {
+		assert.Equal(t, uint8(i), s.Next(1))
+	}
+	assert.Equal(t, uint8(0), s.Next(1))
+	assert.Equal(t, uint8(1), s.Next(1))
+}
+
+func TestTWCCSequencerPerSSRC(t *testing.T) {
+	var s TWCCSequencer
+	assert.Equal(t, uint8(0), s.Next(1))
+	assert.Equal(t, uint8(1), s.Next(1))
+	assert.Equal(t, uint8(0), s.Next(2))
+	assert.Equal(t, uint8(2), s.Next(1))
+	assert.Equal(t, uint8(1), s.Next(2))
+
+	a := &TransportLayerCC{MediaSSRC: 1}
+	b := &TransportLayerCC{MediaSSRC: 3}
+	s.Stamp(a)
+	s.Stamp(b)
+	assert.Equal(t, uint8(3), a.FbPktCount)
+	assert.Equal(t, uint8(0), b.FbPktCount)
+}
+
+func TestTWCCSequencerReset(t *testing.T) {
+	var s TWCCSequencer
+	assert.Equal(t, uint8(0), s.Next(1))
+	assert.Equal(t, uint8(1), s.Next(1))
+	assert.Equal(t, uint8(0), s.Next(2))
+
+	s.Reset()
+	assert.Equal(t, uint8(0), s.Next(1))
+	assert.Equal(t, uint8(0), s.Next(2))
+	assert.Equal(t, uint8(1), s.Next(1))
+}
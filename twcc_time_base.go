@@ -0,0 +1,62 @@
+package rtcp
+
+import "time"
+
+const (
+	// twccReferenceTimeUnit is the resolution of TransportLayerCC.ReferenceTime.
+	twccReferenceTimeUnit = 64 * time.Millisecond
+	// twccReferenceTimeWrap is the modulus of the 24-bit reference time.
+	twccReferenceTimeWrap = 1 << 24
+)
+
+// TWCCTimeBase unwraps the 24-bit reference time of successive
+// TransportLayerCC packets from one feedback sender, so that estimators
+// combining many packets see a single timeline. The reference time of
+// each packet is taken to be the one closest to the previous packet,
+// which holds as long as consecutive packets are less than half a wrap
+// period (about six days) apart.
+//
+// The zero value is ready to use. A TWCCTimeBase is not safe for
+// concurrent use.
+type TWCCTimeBase struct {
+	started bool
+	last    int64
+}
+
+// ReferenceTime returns the unwrapped reference time of t, measured from
+// reference time zero of the first wrap period seen.
+func (b *TWCCTimeBase) ReferenceTime(t *TransportLayerCC) time.Duration {
+	ref := int64(t.ReferenceTime % twccReferenceTimeWrap)
+	if !b.started {
+		b.started = true
+		b.last = ref
+		return time.Duration(ref) * twccReferenceTimeUnit
+	}
+
+	diff := (ref - b.last) & (twccReferenceTimeWrap - 1)
+	if diff >= twccReferenceTimeWrap/2 {
+		// An older packet arriving out of order; don't move the base.
+		return time.Duration(b.last+diff-twccReferenceTimeWrap) * twccReferenceTimeUnit
+	}
+	b.last += diff
+	return time.Duration(b.last) * twccReferenceTimeUnit
+}
+
+// ArrivalTimes returns the arrival time of every packet t reports as
+// received, in the order of t.RecvDeltas, on the same timeline as
+// ReferenceTime.
+func (b *TWCCTimeBase) ArrivalTimes(t *TransportLayerCC) []time.Duration {
+	at := b.ReferenceTime(t)
+	out := make([]time.Duration, len(t.RecvDeltas))
+	for i, d := range t.RecvDeltas {
+		at += time.Duration(d.Delta) * time.Microsecond
+		out[i] = at
+	}
+	return out
+}
+
+// Reset starts a new timeline, taking the reference time of the next
+// packet as if it were the first one seen.
+func (b *TWCCTimeBase) Reset() {
+	*b = TWCCTimeBase{}
+}
@@ -0,0 +1,53 @@
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTWCCTimeBaseWrap(t *testing.T) {
+	var b TWCCTimeBase
+
+	before := &TransportLayerCC{
+		ReferenceTime: 0xFFFFFF,
+		RecvDeltas: []*RecvDelta{
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 1000},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 250},
+		},
+	}
+	after := &TransportLayerCC{
+		ReferenceTime: 0x000001,
+		RecvDeltas: []*RecvDelta{
+			{Type: TypeTCCPacketReceivedLargeDelta, Delta: -250},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 500},
+		},
+	}
+
+	start := time.Duration(0xFFFFFF) * 64 * time.Millisecond
+	assert.Equal(t, []time.Duration{
+		start + 1000*time.Microsecond,
+		start + 1250*time.Microsecond,
+	}, b.ArrivalTimes(before))
+
+	wrapped := time.Duration(0x1000001) * 64 * time.Millisecond
+	assert.Equal(t, []time.Duration{
+		wrapped - 250*time.Microsecond,
+		wrapped + 250*time.Microsecond,
+	}, b.ArrivalTimes(after))
+
+	// A late packet from before the wrap maps back before it without
+	// moving the base.
+	assert.Equal(t, start, b.ReferenceTime(before))
+	assert.Equal(t, wrapped+64*time.Millisecond, b.ReferenceTime(&TransportLayerCC{ReferenceTime: 2}))
+}
+
+func TestTWCCTimeBaseReset(t *testing.T) {
+	var b TWCCTimeBase
+	assert.Equal(t, time.Duration(0xFFFFFF)*64*time.Millisecond, b.ReferenceTime(&TransportLayerCC{ReferenceTime: 0xFFFFFF}))
+
+	// Without the reset this would be unwrapped past 0xFFFFFF.
+	b.Reset()
+	assert.Equal(t, 64*time.Millisecond, b.ReferenceTime(&TransportLayerCC{ReferenceTime: 1}))
+}
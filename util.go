@@ -1,5 +1,11 @@
 package rtcp
 
+import "time"
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900)
+// and the Unix epoch (1970).
+const ntpEpochOffset = 2208988800
+
 // getPadding Returns the padding required to make the length a multiple of 4
 func getPadding(len int) int {
 	if len%4 == 0 {
@@ -20,11 +26,6 @@ func setNBitsOfUint16(src, size, startIndex, val uint16) (uint16, error) {
 	return src | (val << (16 - size - startIndex)), nil
 }
 
-// appendBit32 will left-shift and append n bits of val
-func appendNBitsToUint32(src, n, val uint32) uint32 {
-	return (src << n) | (val & (0xFFFFFFFF >> (32 - n)))
-}
-
 // getNBit get n bits from 1 byte, begin with a position
 func getNBitsFromByte(b byte, begin, n uint16) uint16 {
 	endShift := 8 - (begin + n)
@@ -32,7 +33,29 @@ func getNBitsFromByte(b byte, begin, n uint16) uint16 {
 	return uint16(b&mask) >> endShift
 }
 
-// get24BitFromBytes get 24bits from `[3]byte` slice
-func get24BitsFromBytes(b []byte) uint32 {
-	return uint32(b[0])<<16 + uint32(b[1])<<8 + uint32(b[2])
+// get24 reads the big-endian 24-bit value in the first three bytes of b.
+func get24(b []byte) uint32 {
+	return uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+}
+
+// put24 writes the low 24 bits of v to the first three bytes of b, in
+// big-endian order.
+func put24(b []byte, v uint32) {
+	b[0] = byte(v >> 16)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v)
+}
+
+// ntpToTime converts a 64-bit NTP timestamp to a UTC time.Time
+func ntpToTime(ntp uint64) time.Time {
+	sec := int64(ntp>>32) - ntpEpochOffset
+	nsec := int64((ntp & 0xFFFFFFFF) * 1e9 >> 32)
+	return time.Unix(sec, nsec).UTC()
+}
+
+// timeToNTP converts a time.Time to a 64-bit NTP timestamp
+func timeToNTP(t time.Time) uint64 {
+	sec := uint64(t.Unix()+ntpEpochOffset) << 32
+	frac := uint64(t.Nanosecond()) << 32 / 1e9
+	return sec | frac
 }
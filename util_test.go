@@ -71,3 +71,30 @@ func TestSetNBitsOfUint16(t *testing.T) {
 		})
 	}
 }
+
+func Test24BitHelpers(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		value uint32
+		bytes []byte
+	}{
+		{"zero", 0, []byte{0x00, 0x00, 0x00}},
+		{"one", 1, []byte{0x00, 0x00, 0x01}},
+		{"byteOrder", 0x123456, []byte{0x12, 0x34, 0x56}},
+		{"max", 0xFFFFFF, []byte{0xFF, 0xFF, 0xFF}},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			// put24 must leave the surrounding bytes alone.
+			buf := []byte{0xAA, 0xAA, 0xAA, 0xAA, 0xAA}
+			put24(buf[1:], test.value)
+			assert.Equal(t, append(append([]byte{0xAA}, test.bytes...), 0xAA), buf)
+			assert.Equal(t, test.value, get24(buf[1:]))
+		})
+	}
+
+	// Bits above the low 24 are dropped.
+	buf := make([]byte, 3)
+	put24(buf, 0x1FFFFFF)
+	assert.Equal(t, []byte{0xFF, 0xFF, 0xFF}, buf)
+}